@@ -0,0 +1,307 @@
+// Command meowsum prints or checks meow hashes of files, like sha256sum.
+//
+// Usage:
+//
+//	meowsum [flags] [file ...]
+//	meowsum -c [flags] [checkfile ...]
+//
+// With no file, or when file is -, standard input is read. Hashes are
+// printed as 32 lowercase hex digits, the bytes of the hash in order,
+// followed by two spaces and the file name. With -tag the BSD format
+// "MEOW (file) = hash" is used instead. -json prints one JSON object per
+// file for scripts.
+//
+// With -c, each checkfile is a list of hashes in either format, as printed
+// by meowsum, and each listed file is hashed and reported as OK or FAILED.
+// The exit status is 1 if any file could not be read or did not match,
+// or, with -ignore-missing, if no listed file was verified.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/quillaja/meow"
+)
+
+var (
+	check         bool
+	tag           = flag.Bool("tag", false, "print BSD style hashes: MEOW (file) = hash")
+	jsonLines     = flag.Bool("json", false, "print a JSON object per file")
+	quiet         = flag.Bool("quiet", false, "with -check, don't print OK for each verified file")
+	status        = flag.Bool("status", false, "with -check, print nothing; the exit status reports success")
+	ignoreMissing = flag.Bool("ignore-missing", false, "with -check, don't fail or report for missing files")
+)
+
+func init() {
+	flag.BoolVar(&check, "check", false, "read hashes from the files and check them")
+	flag.BoolVar(&check, "c", false, "shorthand for -check")
+}
+
+// result is a line of -json output.
+type result struct {
+	File   string `json:"file"`
+	Hash   string `json:"hash,omitempty"`
+	Size   *int64 `json:"size,omitempty"`
+	Status string `json:"status,omitempty"` // -check only: OK, FAILED or MISSING
+	Error  string `json:"error,omitempty"`
+}
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage:")
+		fmt.Fprintf(os.Stderr, "%s [flags] [file ...] - print the meow hash of each file\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "%s -c [flags] [checkfile ...] - check hashes listed in each checkfile\n\n", os.Args[0])
+		fmt.Fprintln(os.Stderr, "With no file, or when file is -, read standard input.")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	ok := true
+	if check {
+		ok = checkFiles(out, files)
+	} else {
+		for _, name := range files {
+			ok = printHash(out, name) && ok
+		}
+	}
+	out.Flush()
+	if !ok {
+		os.Exit(1)
+	}
+}
+
+// hashFile hashes the named file, or standard input if name is "-".
+func hashFile(name string) (meow.Hash128, int64, error) {
+	if name == "-" {
+		return meow.HashReader(os.Stdin)
+	}
+	return meow.HashFileMapped(name)
+}
+
+// printHash prints the hash of the named file, reporting whether it could
+// be read.
+func printHash(out *bufio.Writer, name string) bool {
+	hash, size, err := hashFile(name)
+	switch {
+	case *jsonLines:
+		r := result{File: name}
+		if err != nil {
+			r.Error = err.Error()
+		} else {
+			r.Hash = hash.Hex()
+			r.Size = &size
+		}
+		writeJSON(out, r)
+	case err != nil:
+		warn("%v", err)
+	case *tag:
+		escaped, prefix := escapeName(name)
+		fmt.Fprintf(out, "%sMEOW (%s) = %s\n", prefix, escaped, hash.Hex())
+	default:
+		escaped, prefix := escapeName(name)
+		fmt.Fprintf(out, "%s%s  %s\n", prefix, hash.Hex(), escaped)
+	}
+	return err == nil
+}
+
+// counts are the problems found by -check.
+type counts struct {
+	bad     int // improperly formatted lines
+	failed  int // hashes that did not match
+	missing int // files that could not be read
+	lists   int // checksum lists that could not be read, had no checksums or verified no file
+}
+
+// checkFiles verifies the hashes listed in each of lists, reporting
+// whether all of them matched.
+func checkFiles(out *bufio.Writer, lists []string) bool {
+	var c counts
+	for _, list := range lists {
+		if err := checkList(out, list, &c); err != nil {
+			out.Flush()
+			warn("%v", err)
+			c.lists++
+		}
+	}
+
+	if !*status && !*jsonLines {
+		out.Flush()
+		if c.bad > 0 {
+			warn("WARNING: %d %s improperly formatted", c.bad, plural(c.bad, "line is", "lines are"))
+		}
+		if c.missing > 0 {
+			warn("WARNING: %d listed %s could not be read", c.missing, plural(c.missing, "file", "files"))
+		}
+		if c.failed > 0 {
+			warn("WARNING: %d computed %s did NOT match", c.failed, plural(c.failed, "checksum", "checksums"))
+		}
+	}
+	return c.failed == 0 && c.missing == 0 && c.lists == 0
+}
+
+// checkList verifies the hashes listed in the named file, or standard
+// input if list is "-", adding problems to c. With -ignore-missing it is
+// an error if no listed file matched, as when every file is missing.
+func checkList(out *bufio.Writer, list string, c *counts) error {
+	var f io.Reader = os.Stdin
+	if list != "-" {
+		file, err := os.Open(list)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		f = file
+	}
+
+	s := bufio.NewScanner(f)
+	s.Buffer(nil, 1<<20)
+	found, matched := false, false
+	bad := 0
+	for s.Scan() {
+		name, want, err := parseLine(s.Text())
+		if err != nil {
+			if strings.TrimSpace(s.Text()) != "" {
+				bad++
+			}
+			continue
+		}
+		found = true
+
+		hash, _, err := hashFile(name)
+		r := result{File: name, Hash: hash.Hex(), Status: "OK"}
+		switch {
+		case os.IsNotExist(err) && *ignoreMissing:
+			continue
+		case os.IsNotExist(err):
+			r.Status, r.Hash, r.Error = "MISSING", "", err.Error()
+			c.missing++
+		case err != nil:
+			r.Status, r.Hash, r.Error = "FAILED", "", err.Error()
+			c.missing++
+		case !hash.Equal(want):
+			r.Status = "FAILED"
+			c.failed++
+		default:
+			matched = true
+		}
+		report(out, r)
+	}
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("%s: %v", list, err)
+	}
+	if !found {
+		return fmt.Errorf("%s: no properly formatted meow checksum lines found", list)
+	}
+	c.bad += bad
+	if !matched && *ignoreMissing {
+		return fmt.Errorf("%s: no file was verified", list)
+	}
+	return nil
+}
+
+// report prints the result of checking a file, as configured by flags.
+func report(out *bufio.Writer, r result) {
+	switch {
+	case *status:
+	case *jsonLines:
+		writeJSON(out, r)
+	case r.Status == "OK" && *quiet:
+	case r.Status == "MISSING" || r.Error != "":
+		out.Flush()
+		warn("%s", r.Error)
+		fmt.Fprintf(out, "%s: FAILED open or read\n", r.File)
+	default:
+		fmt.Fprintf(out, "%s: %s\n", r.File, r.Status)
+	}
+}
+
+// parseLine parses a line printed by meowsum, in either format, with or
+// without the "\" prefix of an escaped name.
+func parseLine(line string) (name string, hash meow.Hash128, err error) {
+	escaped := strings.HasPrefix(line, "\\")
+	if escaped {
+		line = line[1:]
+	}
+	if strings.HasPrefix(line, "MEOW (") {
+		i := strings.LastIndex(line, ") = ")
+		if i < 0 {
+			return "", hash, fmt.Errorf("invalid line")
+		}
+		hash, err = meow.ParseHex(line[i+len(") = "):])
+		name = line[len("MEOW ("):i]
+		if escaped {
+			name = unescapeName(name)
+		}
+		return name, hash, err
+	}
+
+	const hexLen = 2 * meow.HashSize
+	if len(line) < hexLen+2 || line[hexLen] != ' ' || (line[hexLen+1] != ' ' && line[hexLen+1] != '*') {
+		return "", hash, fmt.Errorf("invalid line")
+	}
+	hash, err = meow.ParseHex(line[:hexLen])
+	name = line[hexLen+2:]
+	if escaped {
+		name = unescapeName(name)
+	}
+	return name, hash, err
+}
+
+// escapeName escapes backslashes and newlines in name like sha256sum does,
+// returning the escaped name and the "\" line prefix that marks it, if
+// anything was escaped.
+func escapeName(name string) (escaped, prefix string) {
+	if !strings.ContainsAny(name, "\\\n") {
+		return name, ""
+	}
+	r := strings.NewReplacer("\\", "\\\\", "\n", "\\n")
+	return r.Replace(name), "\\"
+}
+
+// unescapeName reverses escapeName.
+func unescapeName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '\\' && i+1 < len(name) {
+			i++
+			if name[i] == 'n' {
+				b.WriteByte('\n')
+				continue
+			}
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
+
+// writeJSON prints r as a line of JSON.
+func writeJSON(out *bufio.Writer, r result) {
+	b, _ := json.Marshal(r)
+	out.Write(b)
+	out.WriteByte('\n')
+}
+
+// warn prints a message prefixed with the program name to stderr.
+func warn(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "meowsum: "+format+"\n", args...)
+}
+
+// plural returns one if n is 1, otherwise many.
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/quillaja/meow"
+)
+
+func TestParseLine(t *testing.T) {
+	hash := meow.HashString("meow")
+	for _, name := range []string{"plain", "with space", "back\\slash", "new\nline", "MEOW (x) = y"} {
+		escaped, prefix := escapeName(name)
+		for _, line := range []string{
+			prefix + hash.Hex() + "  " + escaped,
+			prefix + hash.Hex() + " *" + escaped,
+			prefix + "MEOW (" + escaped + ") = " + hash.Hex(),
+		} {
+			if strings.Contains(line, "\n") {
+				t.Fatalf("line %q not escaped", line)
+			}
+			got, h, err := parseLine(line)
+			if err != nil || got != name || !h.Equal(hash) {
+				t.Errorf("parseLine(%q) = %q, %v, %v, want %q, %v", line, got, h, err, name, hash)
+			}
+		}
+	}
+	for _, line := range []string{"", "\\", "MEOW (x)", "\\MEOW (x) = zz", hash.Hex() + " x", hash.Hex()[1:] + "  x"} {
+		if _, _, err := parseLine(line); err == nil {
+			t.Errorf("parseLine(%q) succeeded", line)
+		}
+	}
+}
+
+func TestCheckListIgnoreMissing(t *testing.T) {
+	dir, err := ioutil.TempDir("", "meowsum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	present := filepath.Join(dir, "present")
+	if err := ioutil.WriteFile(present, []byte("meow"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	hash := meow.HashString("meow").Hex()
+	missing := hash + "  " + filepath.Join(dir, "missing") + "\n"
+
+	defer func(v bool) { *ignoreMissing, *status = v, false }(*ignoreMissing)
+	*ignoreMissing, *status = true, true
+	for _, tc := range []struct {
+		list string
+		ok   bool
+	}{
+		{missing + hash + "  " + present + "\n", true},
+		{missing, false},
+		{missing + strings.Repeat("0", len(hash)) + "  " + present + "\n", false},
+	} {
+		list := filepath.Join(dir, "list")
+		if err := ioutil.WriteFile(list, []byte(tc.list), 0666); err != nil {
+			t.Fatal(err)
+		}
+		var c counts
+		err := checkList(bufio.NewWriter(ioutil.Discard), list, &c)
+		if ok := err == nil; ok != tc.ok || c.missing != 0 {
+			t.Errorf("checkList(%q) = %v, %+v, want ok %v and nothing missing", tc.list, err, c, tc.ok)
+		}
+		if ok := checkFiles(bufio.NewWriter(ioutil.Discard), []string{list}); ok != tc.ok {
+			t.Errorf("checkFiles(%q) = %v, want %v", tc.list, ok, tc.ok)
+		}
+	}
+}
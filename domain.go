@@ -0,0 +1,50 @@
+package meow
+
+import "hash"
+
+// domainLabel prefixes every domain name before it is expanded into a
+// seed, so domain seeds never equal ExpandSeed() of a plain key.
+const domainLabel = "meow domain v1\x00"
+
+// Domain hashes with a seed derived from a domain name, so that the same
+// bytes hashed in different domains, such as "file/v1" and "chunk/v1",
+// give unrelated hashes. A Domain is safe for concurrent use.
+//
+// The seed of a domain is
+//
+//	ExpandSeed("meow domain v1\x00" + name)
+//
+// and hashes in the domain are HashSeed() with that seed, so any MeowHash
+// 0.5 implementation with MeowExpandSeed() can reproduce them.
+//
+// Test vectors, in String() format:
+//
+//	domain      data    hash
+//	"chunk/v1"  ""      3C87CEB5-9327277E-1591D2A4-7C344327
+//	"chunk/v1"  "meow"  0B7328F6-C6189EF0-EA764C6A-3790F555
+//	"file/v1"   ""      424E1771-41183829-A40D245C-D0B2ADAA
+//	"file/v1"   "meow"  97F43772-068CF654-725413D2-0BF1A068
+type Domain struct {
+	name string
+	seed Seed
+}
+
+// NewDomain returns the Domain called name.
+func NewDomain(name string) *Domain {
+	return &Domain{
+		name: name,
+		seed: ExpandSeed([]byte(domainLabel + name)),
+	}
+}
+
+// Name returns the name of d.
+func (d *Domain) Name() string { return d.name }
+
+// Seed returns the seed derived for d.
+func (d *Domain) Seed() Seed { return d.seed }
+
+// Hash returns the hash of data in domain d.
+func (d *Domain) Hash(data []byte) Hash128 { return d.seed.Hash(data) }
+
+// New returns a hash.Hash computing hashes in domain d.
+func (d *Domain) New() hash.Hash { return NewSeed(d.seed) }
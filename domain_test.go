@@ -0,0 +1,33 @@
+package meow
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDomainVectors(t *testing.T) {
+	// the same vectors as the Domain doc comment
+	vectors := []struct {
+		domain, data, hash string
+	}{
+		{"chunk/v1", "", "3C87CEB5-9327277E-1591D2A4-7C344327"},
+		{"chunk/v1", "meow", "0B7328F6-C6189EF0-EA764C6A-3790F555"},
+		{"file/v1", "", "424E1771-41183829-A40D245C-D0B2ADAA"},
+		{"file/v1", "meow", "97F43772-068CF654-725413D2-0BF1A068"},
+	}
+	for _, v := range vectors {
+		d := NewDomain(v.domain)
+		got := d.Hash([]byte(v.data))
+		if got.String() != v.hash {
+			t.Errorf("NewDomain(%q).Hash(%q) = %v, want %s", v.domain, v.data, got, v.hash)
+		}
+		if seed := ExpandSeed([]byte("meow domain v1\x00" + v.domain)); d.Seed() != seed {
+			t.Errorf("NewDomain(%q).Seed() is not the documented construction", v.domain)
+		}
+		h := d.New()
+		h.Write([]byte(v.data))
+		if sum := h.Sum(nil); !bytes.Equal(sum, got[:]) {
+			t.Errorf("NewDomain(%q).New() = %x, want %x", v.domain, sum, got)
+		}
+	}
+}
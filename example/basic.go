@@ -52,13 +52,12 @@ func hashBuffer() {
 
 // hashFile hashes a single file's contents.
 func hashFile(filename string) {
-	data, err := ioutil.ReadFile(filename)
+	hash, _, err := meow.HashFile(filename)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
-	hash := meow.Hash(data)
-	fmt.Printf("Hash of \"%s\":\n\t%s\n", filename, meow.String(hash[:]))
+	fmt.Printf("Hash of \"%s\":\n\t%s\n", filename, hash)
 }
 
 // compareTwoFiles hashes and compares the contents of two files.
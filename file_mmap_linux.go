@@ -0,0 +1,80 @@
+//go:build linux
+// +build linux
+
+package meow
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"syscall"
+)
+
+// HashFileMapped hashes the contents of the named file like HashFile(),
+// but memory maps the file and hashes the mapping in place, saving a copy
+// for large files that are already in the page cache. The mapping is
+// hashed a chunk at a time, so other goroutines and the garbage collector
+// aren't held up while a large file is hashed.
+//
+// Files that can't be mapped, such as pipes and empty files, are streamed
+// with HashReader() instead. If the file shrinks while it is hashed, an
+// error is returned instead of crashing.
+func HashFileMapped(name string) (Hash128, int64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Hash128{}, 0, err
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return Hash128{}, 0, err
+	}
+	size := fi.Size()
+	if !fi.Mode().IsRegular() || size <= 0 || int64(int(size)) != size {
+		return HashReader(f)
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return HashReader(f)
+	}
+	defer syscall.Munmap(data)
+	syscall.Madvise(data, syscall.MADV_SEQUENTIAL)
+
+	h, ok := sumMapped(data)
+	if !ok {
+		return Hash128{}, 0, fmt.Errorf("meow: %s was truncated while it was hashed", name)
+	}
+	return h, size, nil
+}
+
+// mappedChunkSize is how much of a mapping is hashed by one call into the
+// native implementation, which can't be preempted. It is a multiple of
+// BlockSize.
+const mappedChunkSize = 1 << 20
+
+// sumMapped hashes the memory mapped data using MeowDefaultSeed, in chunks
+// of mappedChunkSize. It returns false instead of crashing if a page of
+// data can't be read, which happens when the file is truncated after it is
+// mapped.
+func sumMapped(data []byte) (h Hash128, ok bool) {
+	defer debug.SetPanicOnFault(debug.SetPanicOnFault(true))
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	lanes := MeowDefaultSeed
+	full := len(data) &^ (BlockSize - 1)
+	for off := 0; off < full; off += mappedChunkSize {
+		end := off + mappedChunkSize
+		if end > full {
+			end = full
+		}
+		absorbBlocks(&lanes, data[off:end])
+	}
+	var buf [BlockSize]byte
+	copy(buf[:], data[full:])
+	return finish(&lanes, uint64(len(data)), &buf), true
+}
@@ -0,0 +1,55 @@
+package meow
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+// tempFile writes data to a new temporary file and returns its name.
+func tempFile(t testing.TB, data []byte) string {
+	f, err := ioutil.TempFile("", "meow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	return f.Name()
+}
+
+func TestHashFileMapped(t *testing.T) {
+	data := testData(3<<20 + 777)
+	// around page and chunk boundaries
+	for _, n := range []int{1, 15, 16, 17, 4095, 4096, 4097, 1<<20 - 1, 1 << 20, 1<<20 + 1, len(data)} {
+		name := tempFile(t, data[:n])
+		h, size, err := HashFileMapped(name)
+		os.Remove(name)
+		if err != nil || size != int64(n) {
+			t.Fatalf("HashFileMapped(%d bytes) = %d bytes, %v", n, size, err)
+		}
+		if want := Hash(data[:n]); !bytes.Equal(h[:], want) {
+			t.Errorf("HashFileMapped(%d bytes) = %x, want %x", n, h, want)
+		}
+	}
+}
+
+// benchmarkFile benchmarks hashFile on a 64 MiB file.
+func benchmarkFile(b *testing.B, hashFile func(string) (Hash128, int64, error)) {
+	const size = 64 << 20
+	name := tempFile(b, testData(size))
+	defer os.Remove(name)
+	b.SetBytes(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := hashFile(name); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHashFileMapped(b *testing.B) { benchmarkFile(b, HashFileMapped) }
+
+func BenchmarkHashFile(b *testing.B) { benchmarkFile(b, HashFile) }
@@ -0,0 +1,541 @@
+// Package meow implements the "meow hash" developed by Molly Rocket, Inc.
+// It implements a non-cryptographic hash.
+// See header comment in meow_amd64.s for more details,
+// or the repo at https://github.com/cmuratori/meow_hash.
+//
+// On amd64 the package uses a Go assembly port of the upstream AES-NI
+// implementation, called directly without cgo, if the CPU supports it.
+// Everywhere else (or with the purego build tag) a portable pure Go port
+// with a software AES round is used instead. Both produce identical hashes.
+// Implementation() reports which one is in use.
+//
+// One will primarily want to use the Hash() function. This package
+// also provides compatibility with the hash.Hash interface using New().
+//
+// Empty input, nil or zero length, is hashed like any other: every
+// function accepts it and gives the same hash as upstream MeowHash() with
+// Len 0. With MeowDefaultSeed that is
+//
+//	75A7B555-0383265E-657CA02A-5859C045
+package meow
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"unsafe"
+)
+
+// Size constants.
+const (
+	SeedSize  = 128 // 128 bytes for a seed.
+	HashSize  = 16  // 16 bytes for a hash.
+	BlockSize = 256 // 256 byte blocks.
+
+	// Version info.
+	Version     = 5
+	VersionName = "0.5/calico"
+)
+
+// MeowDefaultSeed is a seed to use.
+//
+// The default seed is now a "nothing-up-our-sleeves" number for good measure.
+// You may verify that it is just an encoding of Pi.
+var MeowDefaultSeed = [SeedSize]byte{
+	0x32, 0x43, 0xF6, 0xA8, 0x88, 0x5A, 0x30, 0x8D,
+	0x31, 0x31, 0x98, 0xA2, 0xE0, 0x37, 0x07, 0x34,
+	0x4A, 0x40, 0x93, 0x82, 0x22, 0x99, 0xF3, 0x1D,
+	0x00, 0x82, 0xEF, 0xA9, 0x8E, 0xC4, 0xE6, 0xC8,
+	0x94, 0x52, 0x82, 0x1E, 0x63, 0x8D, 0x01, 0x37,
+	0x7B, 0xE5, 0x46, 0x6C, 0xF3, 0x4E, 0x90, 0xC6,
+	0xCC, 0x0A, 0xC2, 0x9B, 0x7C, 0x97, 0xC5, 0x0D,
+	0xD3, 0xF8, 0x4D, 0x5B, 0x5B, 0x54, 0x70, 0x91,
+	0x79, 0x21, 0x6D, 0x5D, 0x98, 0x97, 0x9F, 0xB1,
+	0xBD, 0x13, 0x10, 0xBA, 0x69, 0x8D, 0xFB, 0x5A,
+	0xC2, 0xFF, 0xD7, 0x2D, 0xBD, 0x01, 0xAD, 0xFB,
+	0x7B, 0x8E, 0x1A, 0xFE, 0xD6, 0xA2, 0x67, 0xE9,
+	0x6B, 0xA7, 0xC9, 0x04, 0x5F, 0x12, 0xC7, 0xF9,
+	0x92, 0x4A, 0x19, 0x94, 0x7B, 0x39, 0x16, 0xCF,
+	0x70, 0x80, 0x1F, 0x2E, 0x28, 0x58, 0xEF, 0xC1,
+	0x66, 0x36, 0x92, 0x0D, 0x87, 0x15, 0x74, 0xE6,
+}
+
+// Implementation returns the name of the MeowHash implementation in use:
+// "aesni" for the AES-NI assembly on amd64, chosen at startup when the CPU
+// supports AES-NI, SSE4.1 and AVX, or "generic" for the portable Go version.
+func Implementation() string { return implementation }
+
+// Hash data to 16 byte hash using MeowDefaultSeed. data may be nil or
+// empty.
+func Hash(data []byte) []byte {
+	b := sum(&MeowDefaultSeed, data)
+	return b[:]
+}
+
+// HashSeed hashes data using seed.
+func HashSeed(seed [SeedSize]byte, data []byte) []byte {
+	b := sum(&seed, data)
+	return b[:]
+}
+
+// HashBatch hashes each of inputs using seed and stores the hashes at the
+// same positions in out. The result is the same as calling HashSeed() on
+// each input, but nothing is allocated and the native implementation is
+// entered once for the whole batch, which matters when inputs are small.
+// On amd64, consecutive inputs shorter than BlockSize are hashed two at a
+// time by a kernel that interleaves their AES rounds, so HashBatch is the
+// fastest way to hash many short keys.
+// Panics if out is shorter than inputs.
+func HashBatch(seed [SeedSize]byte, inputs [][]byte, out [][HashSize]byte) {
+	if len(out) < len(inputs) {
+		panic("meow: HashBatch out is shorter than inputs")
+	}
+	sumBatch(&seed, inputs, out)
+}
+
+// HashVec hashes the concatenation of parts using seed, without
+// concatenating them: the result equals HashSeed() of the joined parts.
+// Only the partial block at the end of each part is buffered, on the stack.
+// It does not allocate.
+//
+// The boundaries between parts don't affect the hash, so ("ab", "c") and
+// ("a", "bc") collide. Use HashVecPrefixed() when that matters.
+func HashVec(seed [SeedSize]byte, parts ...[]byte) Hash128 {
+	h := meowHash{seed: seed}
+	h.Reset()
+	for _, p := range parts {
+		h.Write(p)
+	}
+	return h.sum128()
+}
+
+// HashVecPrefixed hashes parts using seed, with each part preceded by its
+// length as 8 little endian bytes, so the boundaries between parts affect
+// the hash and ("ab", "c") and ("a", "bc") differ. The result equals
+// HashSeed() of the parts joined that way. Like HashVec(), nothing is
+// concatenated and it does not allocate.
+//
+// Test vectors, with MeowDefaultSeed, in String() format:
+//
+//	("ab", "c")   C8C1B942-7B64E2CD-5D073693-BA82961C
+//	("a", "bc")   3B14F709-E5D81A2F-EAECEA31-CA21392B
+//	("", "meow")  34986F3D-22689DA3-8165887B-3C3A8983
+func HashVecPrefixed(seed [SeedSize]byte, parts ...[]byte) Hash128 {
+	h := meowHash{seed: seed}
+	h.Reset()
+	var length [8]byte
+	for _, p := range parts {
+		binary.LittleEndian.PutUint64(length[:], uint64(len(p)))
+		h.Write(length[:])
+		h.Write(p)
+	}
+	return h.sum128()
+}
+
+// HashNonce hashes data using seed, with nonce mixed in. It is a separate,
+// non-default mode: MeowHash() has room for a 128-bit nonce next to the
+// length injest, which upstream leaves zero, and HashNonce puts nonce
+// there. Different nonces randomize the hash per message without needing
+// a new seed. A zero nonce gives the same result as HashSeed().
+//
+// The nonce is not secret, and Meow is not a MAC: this only changes which
+// hash function of the family is used.
+//
+// Test vectors, with MeowDefaultSeed and the nonce bytes 0x00, 0x01, ... 0x0f,
+// in String() format:
+//
+//	""                                             9A4A4611-5C4E9664-659D1F97-1A7B81C7
+//	"meow"                                         7CE0AA26-BF9288F1-09852B10-6E379EB6
+//	"The quick brown fox jumps over the lazy dog"  8231A40E-645DF008-F46B9B35-882A14FE
+func HashNonce(seed [SeedSize]byte, nonce [16]byte, data []byte) Hash128 {
+	lanes := seed
+	full := len(data) &^ (BlockSize - 1)
+	absorbBlocks(&lanes, data[:full])
+
+	var buf [BlockSize]byte
+	copy(buf[:], data[full:])
+	return finishNonce(&lanes, uint64(len(data)), &buf, &nonce)
+}
+
+// Hash16 hashes a 16 byte key using MeowDefaultSeed. The result is the
+// same as Hash(key[:]). Because the length is a multiple of 16, there is no
+// partial residual to mask or shift, and nothing is allocated.
+func Hash16(key [16]byte) Hash128 { return sumPadded(&MeowDefaultSeed, key[:]) }
+
+// Hash32 hashes a 32 byte key using MeowDefaultSeed, like Hash16().
+func Hash32(key [32]byte) Hash128 { return sumPadded(&MeowDefaultSeed, key[:]) }
+
+// Hash64 hashes a 64 byte key using MeowDefaultSeed, like Hash16().
+func Hash64(key [64]byte) Hash128 { return sumPadded(&MeowDefaultSeed, key[:]) }
+
+// HashPadded hashes data using MeowDefaultSeed. The result is the same as
+// Hash(data), but the caller guarantees the buffer is padded: cap(data)
+// must reach len(data) rounded up to a multiple of 16. The residual is then
+// loaded straight from data, skipping the page boundary logic needed to
+// avoid reading past the end. The padding bytes may hold anything.
+// Panics if data is not padded.
+func HashPadded(data []byte) Hash128 {
+	padded := (len(data) + 15) &^ 15
+	if cap(data) < padded {
+		panic("meow: HashPadded data is not padded to a multiple of 16")
+	}
+	return sumPadded(&MeowDefaultSeed, data[:padded][:len(data)])
+}
+
+// ExpandSeed derives a full seed from a key of any length, such as a short
+// ID or passphrase. It matches MeowExpandSeed() from upstream: the 8-byte
+// little endian length of key is absorbed with MeowDefaultSeed, followed
+// by key repeated (256/len(key))+2 times, and the eight lanes after the
+// final mix are the seed.
+//
+// Upstream divides by zero for an empty key. Here an empty key absorbs only
+// its length.
+func ExpandSeed(key []byte) [SeedSize]byte {
+	h := meowHash{seed: MeowDefaultSeed}
+	h.Reset()
+
+	// always injest an 8-byte length, to get identical results everywhere
+	var length [8]byte
+	binary.LittleEndian.PutUint64(length[:], uint64(len(key)))
+	h.Write(length[:])
+	if len(key) > 0 {
+		for count := BlockSize/len(key) + 2; count > 0; count-- {
+			h.Write(key)
+		}
+	}
+
+	seed := h.lanes
+	finish(&seed, h.len, &h.buf)
+	return seed
+}
+
+// Sum64 hashes data using MeowDefaultSeed and returns the first 8 bytes of
+// the hash as a little endian uint64. It does not allocate.
+func Sum64(data []byte) uint64 {
+	b := sum(&MeowDefaultSeed, data)
+	return binary.LittleEndian.Uint64(b[0:8])
+}
+
+// Sum128 hashes data using MeowDefaultSeed and returns the hash as two
+// little endian uint64s: lo is bytes 0-7 and hi is bytes 8-15.
+// It does not allocate.
+func Sum128(data []byte) (lo, hi uint64) {
+	b := sum(&MeowDefaultSeed, data)
+	return binary.LittleEndian.Uint64(b[0:8]), binary.LittleEndian.Uint64(b[8:16])
+}
+
+// HashString hashes the bytes of s using MeowDefaultSeed. Unlike
+// Hash([]byte(s)), s is not copied. It does not allocate.
+func HashString(s string) Hash128 {
+	return sum(&MeowDefaultSeed, stringBytes(s))
+}
+
+// HashInto hashes data using MeowDefaultSeed and stores the hash in dst.
+// It does not allocate.
+func HashInto(dst *[HashSize]byte, data []byte) {
+	*dst = sum(&MeowDefaultSeed, data)
+}
+
+// String prints 4 32-bit chunks in hex. High bytes are on left. Each chunk
+// is zero padded to 8 digits, so the result can be parsed by ParseHash().
+// Panics if len(hash) is less than HashSize.
+//
+// The `%x` verb in package fmt prints identical hex, but with high
+// bytes on the right.
+func String(hash []byte) string {
+	return fmt.Sprintf("%08X-%08X-%08X-%08X",
+		binary.LittleEndian.Uint32(hash[12:16]),
+		binary.LittleEndian.Uint32(hash[8:12]),
+		binary.LittleEndian.Uint32(hash[4:8]),
+		binary.LittleEndian.Uint32(hash[0:4]))
+}
+
+// meowHash implements hash.Hash using the streaming Meow Hash construction.
+// It uses a constant amount of memory regardless of how much is written.
+type meowHash struct {
+	seed  [SeedSize]byte
+	lanes [SeedSize]byte  // the eight 128-bit accumulation lanes
+	buf   [BlockSize]byte // residual not yet absorbed
+	n     int             // number of bytes in buf
+	len   uint64          // total bytes written
+}
+
+// New makes a new hash.Hash using the meowHash type and MeowDefaultSeed.
+// Hashing the same bytes gives the same result as Hash(), regardless of
+// how they are split across calls to Write(). Sum() before any Write()
+// returns the hash of empty input.
+//
+// The hash.Hash also implements encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler to save and restore its state, and has a
+// Clone() hash.Hash method.
+func New() hash.Hash { return NewSeed(MeowDefaultSeed) }
+
+// NewSeed makes a new hash.Hash like New() that gives the same result as
+// HashSeed() with seed.
+func NewSeed(seed [SeedSize]byte) hash.Hash {
+	h := &meowHash{seed: seed}
+	h.Reset()
+	return h
+}
+
+// Write p to h.
+func (h *meowHash) Write(p []byte) (n int, err error) {
+	n = len(p)
+	h.len += uint64(n)
+
+	// fill and absorb any buffered residual first
+	if h.n > 0 {
+		c := copy(h.buf[h.n:], p)
+		h.n += c
+		p = p[c:]
+		if h.n < BlockSize {
+			return
+		}
+		absorbBlocks(&h.lanes, h.buf[:])
+		h.n = 0
+	}
+
+	// absorb full blocks directly from p, and keep the rest
+	full := len(p) &^ (BlockSize - 1)
+	absorbBlocks(&h.lanes, p[:full])
+	h.n = copy(h.buf[:], p[full:])
+	return
+}
+
+// WriteString writes the bytes of s to h without copying s.
+func (h *meowHash) WriteString(s string) (n int, err error) {
+	return h.Write(stringBytes(s))
+}
+
+// WriteByte writes c to h.
+func (h *meowHash) WriteByte(c byte) error {
+	h.buf[h.n] = c
+	h.n++
+	h.len++
+	if h.n == BlockSize {
+		absorbBlocks(&h.lanes, h.buf[:])
+		h.n = 0
+	}
+	return nil
+}
+
+// stringBytes returns the bytes of s without copying. They must not be
+// modified.
+func stringBytes(s string) []byte {
+	return *(*[]byte)(unsafe.Pointer(&struct {
+		string
+		int
+	}{s, len(s)}))
+}
+
+// Sum appends the hash of the data written via Write() to b and returns
+// the resulting slice. It does not change the state of h.
+func (h *meowHash) Sum(b []byte) []byte {
+	x := h.sum128()
+	return append(b, x[:]...)
+}
+
+// sum128 returns the hash of the data written via Write().
+func (h *meowHash) sum128() [HashSize]byte {
+	lanes := h.lanes
+	return finish(&lanes, h.len, &h.buf)
+}
+
+// Reset erases the data accumulated via Write().
+func (h *meowHash) Reset() {
+	h.lanes = h.seed
+	h.n = 0
+	h.len = 0
+}
+
+// Size of hash.
+func (h *meowHash) Size() int { return HashSize }
+
+// Clone returns an independent copy of h.
+func (h *meowHash) Clone() hash.Hash {
+	c := *h
+	return &c
+}
+
+// The marshaled state is:
+//
+//	magic      4 bytes, stateMagic for New(), stateMagic64 for New64()
+//	           or stateMagic32 for New32()
+//	format     1 byte, stateFormat
+//	Version    1 byte
+//	seed       8 bytes, seedFingerprint() of the seed
+//	lanes      SeedSize bytes
+//	length     8 bytes, total bytes written
+//	residual   length%BlockSize bytes
+//
+// All integers are big endian.
+const (
+	stateMagic       = "meow"
+	stateMagic64     = "mw64"
+	stateMagic32     = "mw32"
+	stateFormat      = 1
+	stateHeaderSize  = len(stateMagic) + 1 + 1 + 8
+	marshaledMinSize = stateHeaderSize + SeedSize + 8
+)
+
+// Errors returned when restoring a marshaled hash state.
+var (
+	errStateInvalid = errors.New("meow: invalid hash state")
+	errStateFormat  = errors.New("meow: unsupported hash state format")
+	errStateVersion = errors.New("meow: hash state is from a different Version")
+	errStateSeed    = errors.New("meow: hash state is from a different seed")
+)
+
+// seedFingerprint identifies seed without revealing it: the first 8 bytes
+// of the hash of seed, using MeowDefaultSeed.
+func seedFingerprint(seed *[SeedSize]byte) uint64 {
+	b := sum(&MeowDefaultSeed, seed[:])
+	return binary.LittleEndian.Uint64(b[0:8])
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. The state can be
+// restored with UnmarshalBinary() on a hash of the same size using the
+// same seed.
+func (h *meowHash) MarshalBinary() ([]byte, error) { return h.marshal(stateMagic), nil }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It rejects state
+// marshaled by a hash of a different size, by a different Version of the
+// hash or with a different seed.
+func (h *meowHash) UnmarshalBinary(b []byte) error { return h.unmarshal(stateMagic, b) }
+
+// marshal returns the marshaled state of h, starting with magic.
+func (h *meowHash) marshal(magic string) []byte {
+	b := make([]byte, 0, marshaledMinSize+h.n)
+	b = append(b, magic...)
+	b = append(b, stateFormat, Version)
+	b = appendUint64(b, seedFingerprint(&h.seed))
+	b = append(b, h.lanes[:]...)
+	b = appendUint64(b, h.len)
+	b = append(b, h.buf[:h.n]...)
+	return b
+}
+
+// unmarshal restores the state of h from b, which must start with magic.
+func (h *meowHash) unmarshal(magic string, b []byte) error {
+	if len(b) < marshaledMinSize || string(b[:len(magic)]) != magic {
+		return errStateInvalid
+	}
+	header := b[len(stateMagic):stateHeaderSize]
+	if header[0] != stateFormat {
+		return errStateFormat
+	}
+	if header[1] != Version {
+		return errStateVersion
+	}
+	if binary.BigEndian.Uint64(header[2:]) != seedFingerprint(&h.seed) {
+		return errStateSeed
+	}
+	b = b[stateHeaderSize:]
+
+	length := binary.BigEndian.Uint64(b[SeedSize:])
+	residual := b[SeedSize+8:]
+	if uint64(len(residual)) != length%BlockSize {
+		return errStateInvalid
+	}
+
+	copy(h.lanes[:], b[:SeedSize])
+	h.len = length
+	h.n = copy(h.buf[:], residual)
+	return nil
+}
+
+// appendUint64 appends x to b in big endian order.
+func appendUint64(b []byte, x uint64) []byte {
+	var a [8]byte
+	binary.BigEndian.PutUint64(a[:], x)
+	return append(b, a[:]...)
+}
+
+// BlockSize is the ideal size of blocks.
+func (h *meowHash) BlockSize() int { return BlockSize }
+
+// meowHash64 implements hash.Hash64 by truncating the 128-bit hash.
+type meowHash64 struct{ meowHash }
+
+// New64 makes a new hash.Hash64 using MeowDefaultSeed. Sum64() is the
+// first 8 bytes of the 128-bit hash as a little endian uint64, the same
+// as the Sum64() function.
+func New64() hash.Hash64 {
+	h := &meowHash64{meowHash{seed: MeowDefaultSeed}}
+	h.Reset()
+	return h
+}
+
+// Sum64 returns the first 8 bytes of the hash as a little endian uint64.
+func (h *meowHash64) Sum64() uint64 {
+	x := h.sum128()
+	return binary.LittleEndian.Uint64(x[0:8])
+}
+
+// Sum appends Sum64() to b in big endian order, like the 64-bit hashes in
+// the standard library.
+func (h *meowHash64) Sum(b []byte) []byte {
+	var x [8]byte
+	binary.BigEndian.PutUint64(x[:], h.Sum64())
+	return append(b, x[:]...)
+}
+
+// Size of hash.
+func (h *meowHash64) Size() int { return 8 }
+
+// MarshalBinary implements encoding.BinaryMarshaler like New() does. The
+// state can only be restored by a hash from New64().
+func (h *meowHash64) MarshalBinary() ([]byte, error) { return h.marshal(stateMagic64), nil }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler like New() does.
+// It only accepts state marshaled by a hash from New64().
+func (h *meowHash64) UnmarshalBinary(b []byte) error { return h.unmarshal(stateMagic64, b) }
+
+// Clone returns an independent copy of h.
+func (h *meowHash64) Clone() hash.Hash {
+	c := *h
+	return &c
+}
+
+// meowHash32 implements hash.Hash32 by truncating the 128-bit hash.
+type meowHash32 struct{ meowHash }
+
+// New32 makes a new hash.Hash32 using MeowDefaultSeed. Sum32() is the
+// first 4 bytes of the 128-bit hash as a little endian uint32.
+func New32() hash.Hash32 {
+	h := &meowHash32{meowHash{seed: MeowDefaultSeed}}
+	h.Reset()
+	return h
+}
+
+// Sum32 returns the first 4 bytes of the hash as a little endian uint32.
+func (h *meowHash32) Sum32() uint32 {
+	x := h.sum128()
+	return binary.LittleEndian.Uint32(x[0:4])
+}
+
+// Sum appends Sum32() to b in big endian order, like the 32-bit hashes in
+// the standard library.
+func (h *meowHash32) Sum(b []byte) []byte {
+	var x [4]byte
+	binary.BigEndian.PutUint32(x[:], h.Sum32())
+	return append(b, x[:]...)
+}
+
+// Size of hash.
+func (h *meowHash32) Size() int { return 4 }
+
+// MarshalBinary implements encoding.BinaryMarshaler like New() does. The
+// state can only be restored by a hash from New32().
+func (h *meowHash32) MarshalBinary() ([]byte, error) { return h.marshal(stateMagic32), nil }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler like New() does.
+// It only accepts state marshaled by a hash from New32().
+func (h *meowHash32) UnmarshalBinary(b []byte) error { return h.unmarshal(stateMagic32, b) }
+
+// Clone returns an independent copy of h.
+func (h *meowHash32) Clone() hash.Hash {
+	c := *h
+	return &c
+}
@@ -0,0 +1,141 @@
+package meow
+
+import (
+	"crypto/subtle"
+	"database/sql/driver"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Hash128 is a 128-bit meow hash value. Hash128 is comparable, so it can be
+// used as a map key.
+//
+// Hash128 implements encoding.TextMarshaler and encoding.TextUnmarshaler
+// using the String() format, so it is also a string in JSON. It implements
+// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler using the raw
+// HashSize bytes, and sql.Scanner and driver.Valuer for storing in databases.
+type Hash128 [HashSize]byte
+
+// errHashLen is returned when decoding a Hash128 from the wrong number of bytes.
+var errHashLen = errors.New("meow: hash must be 16 bytes")
+
+// hashBase32 is the standard base32 alphabet without padding.
+var hashBase32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// String prints h as 4 zero padded 32-bit chunks in hex, high bytes on left,
+// like the String() function. ParseHash() reverses it.
+func (h Hash128) String() string { return String(h[:]) }
+
+// Hex encodes the bytes of h in order as 32 lowercase hex digits, as the
+// `%x` verb in package fmt would. ParseHex() reverses it.
+func (h Hash128) Hex() string { return hex.EncodeToString(h[:]) }
+
+// Base32 encodes the bytes of h with the standard base32 alphabet and no
+// padding. ParseBase32() reverses it.
+func (h Hash128) Base32() string { return hashBase32.EncodeToString(h[:]) }
+
+// Base64 encodes the bytes of h with the URL safe base64 alphabet and no
+// padding. ParseBase64() reverses it.
+func (h Hash128) Base64() string { return base64.RawURLEncoding.EncodeToString(h[:]) }
+
+// Equal reports whether h and other are the same, in constant time.
+func (h Hash128) Equal(other Hash128) bool {
+	return subtle.ConstantTimeCompare(h[:], other[:]) == 1
+}
+
+// ParseHash parses the String() format of a hash, such as
+// "0000ABCD-01234567-89ABCDEF-DEADBEEF". Hex digits may be in either case.
+func ParseHash(s string) (Hash128, error) {
+	var h Hash128
+	if len(s) != 35 || s[8] != '-' || s[17] != '-' || s[26] != '-' {
+		return h, fmt.Errorf("meow: invalid hash %q", s)
+	}
+	// chunks are printed high bytes first, from the end of the hash
+	for i := 0; i < 4; i++ {
+		var chunk [4]byte
+		if _, err := hex.Decode(chunk[:], []byte(s[i*9:i*9+8])); err != nil {
+			return Hash128{}, fmt.Errorf("meow: invalid hash %q", s)
+		}
+		j := HashSize - 4*(i+1)
+		h[j+0], h[j+1], h[j+2], h[j+3] = chunk[3], chunk[2], chunk[1], chunk[0]
+	}
+	return h, nil
+}
+
+// ParseHex parses the Hex() format of a hash.
+func ParseHex(s string) (Hash128, error) {
+	return decodeHash(s, hex.DecodeString)
+}
+
+// ParseBase32 parses the Base32() format of a hash.
+func ParseBase32(s string) (Hash128, error) {
+	return decodeHash(s, hashBase32.DecodeString)
+}
+
+// ParseBase64 parses the Base64() format of a hash.
+func ParseBase64(s string) (Hash128, error) {
+	return decodeHash(s, base64.RawURLEncoding.DecodeString)
+}
+
+// decodeHash decodes s with decode and checks it is HashSize bytes.
+func decodeHash(s string, decode func(string) ([]byte, error)) (Hash128, error) {
+	var h Hash128
+	b, err := decode(s)
+	if err != nil {
+		return h, fmt.Errorf("meow: invalid hash %q: %v", s, err)
+	}
+	if len(b) != HashSize {
+		return h, errHashLen
+	}
+	copy(h[:], b)
+	return h, nil
+}
+
+// MarshalText implements encoding.TextMarshaler using the String() format.
+func (h Hash128) MarshalText() ([]byte, error) { return []byte(h.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseHash().
+func (h *Hash128) UnmarshalText(text []byte) error {
+	x, err := ParseHash(string(text))
+	if err != nil {
+		return err
+	}
+	*h = x
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns the
+// HashSize bytes of h.
+func (h Hash128) MarshalBinary() ([]byte, error) { return h[:], nil }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. data must be
+// HashSize bytes long.
+func (h *Hash128) UnmarshalBinary(data []byte) error {
+	if len(data) != HashSize {
+		return errHashLen
+	}
+	copy(h[:], data)
+	return nil
+}
+
+// Value implements driver.Valuer. Hashes are stored as HashSize bytes.
+func (h Hash128) Value() (driver.Value, error) { return h[:], nil }
+
+// Scan implements sql.Scanner. It accepts the HashSize bytes stored by
+// Value(), or the String() format as a string or []byte.
+func (h *Hash128) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case []byte:
+		if len(src) == HashSize {
+			copy(h[:], src)
+			return nil
+		}
+		return h.UnmarshalText(src)
+	case string:
+		return h.UnmarshalText([]byte(src))
+	}
+	return fmt.Errorf("meow: cannot scan %T into Hash128", src)
+}
@@ -0,0 +1,115 @@
+package meow
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// leadingZeros is a hash whose String() chunks have leading zeros.
+var leadingZeros = Hash128{
+	0x01, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00,
+	0x00, 0x00, 0xef, 0xbe, 0xcd, 0xab, 0x00, 0x00,
+}
+
+func TestHash128String(t *testing.T) {
+	const want = "0000ABCD-BEEF0000-00000002-00000001"
+	if got := leadingZeros.String(); got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+	for _, s := range []string{want, "0000abcd-beef0000-00000002-00000001"} {
+		h, err := ParseHash(s)
+		if err != nil || h != leadingZeros {
+			t.Errorf("ParseHash(%q) = %x, %v, want %x", s, h, err, leadingZeros)
+		}
+	}
+
+	h := Hash128(sum(&MeowDefaultSeed, testData(100)))
+	if got, err := ParseHash(h.String()); err != nil || got != h {
+		t.Errorf("ParseHash(%q) = %x, %v, want %x", h.String(), got, err, h)
+	}
+
+	for _, s := range []string{
+		"",
+		"ABCD-BEEF0000-00000002-00000001",
+		"0000ABCD-BEEF0000-00000002-000000011",
+		"0000ABCDBEEF0000000000020000000100",
+		"0000ABCD BEEF0000 00000002 00000001",
+		"0000ABCG-BEEF0000-00000002-00000001",
+		"+000ABCD-BEEF0000-00000002-00000001",
+	} {
+		if _, err := ParseHash(s); err == nil {
+			t.Errorf("ParseHash(%q) succeeded", s)
+		}
+	}
+}
+
+func TestHash128Encodings(t *testing.T) {
+	encodings := []struct {
+		name   string
+		encode func(Hash128) string
+		parse  func(string) (Hash128, error)
+		want   string
+	}{
+		{"Hex", Hash128.Hex, ParseHex, "01000000020000000000efbecdab0000"},
+		{"Base32", Hash128.Base32, ParseBase32, "AEAAAAACAAAAAAAA567M3KYAAA"},
+		{"Base64", Hash128.Base64, ParseBase64, "AQAAAAIAAAAAAO--zasAAA"},
+	}
+	for _, e := range encodings {
+		got := e.encode(leadingZeros)
+		if got != e.want {
+			t.Errorf("%s() = %s, want %s", e.name, got, e.want)
+		}
+		if h, err := e.parse(got); err != nil || h != leadingZeros {
+			t.Errorf("Parse%s(%q) = %x, %v, want %x", e.name, got, h, err, leadingZeros)
+		}
+		// one byte short, one byte long, and not the encoding at all
+		for _, s := range []string{e.encode(Hash128{})[:len(got)-2], got + e.encode(Hash128{})[:2], "!" + got[1:]} {
+			if _, err := e.parse(s); err == nil {
+				t.Errorf("Parse%s(%q) succeeded", e.name, s)
+			}
+		}
+	}
+}
+
+func TestHash128Marshal(t *testing.T) {
+	b, err := json.Marshal(map[string]Hash128{"h": leadingZeros})
+	if want := `{"h":"0000ABCD-BEEF0000-00000002-00000001"}`; err != nil || string(b) != want {
+		t.Errorf("json.Marshal() = %s, %v, want %s", b, err, want)
+	}
+	var m map[string]Hash128
+	if err := json.Unmarshal(b, &m); err != nil || m["h"] != leadingZeros {
+		t.Errorf("json.Unmarshal(%s) = %x, %v, want %x", b, m["h"], err, leadingZeros)
+	}
+	if err := json.Unmarshal([]byte(`{"h":"nope"}`), &m); err == nil {
+		t.Errorf("json.Unmarshal of an invalid hash succeeded")
+	}
+
+	bin, _ := leadingZeros.MarshalBinary()
+	var h Hash128
+	if err := h.UnmarshalBinary(bin); err != nil || h != leadingZeros {
+		t.Errorf("UnmarshalBinary() = %x, %v, want %x", h, err, leadingZeros)
+	}
+	if err := h.UnmarshalBinary(bin[1:]); err != errHashLen {
+		t.Errorf("UnmarshalBinary(15 bytes) = %v, want %v", err, errHashLen)
+	}
+}
+
+func TestHash128SQL(t *testing.T) {
+	v, err := leadingZeros.Value()
+	if b, ok := v.([]byte); err != nil || !ok || !bytes.Equal(b, leadingZeros[:]) {
+		t.Fatalf("Value() = %v, %v, want %x", v, err, leadingZeros)
+	}
+	for _, src := range []interface{}{v, leadingZeros.String(), []byte(leadingZeros.String())} {
+		var h Hash128
+		if err := h.Scan(src); err != nil || h != leadingZeros {
+			t.Errorf("Scan(%v) = %x, %v, want %x", src, h, err, leadingZeros)
+		}
+	}
+	for _, src := range []interface{}{nil, 42, []byte{1, 2, 3}, "nope"} {
+		var h Hash128
+		if err := h.Scan(src); err == nil {
+			t.Errorf("Scan(%v) succeeded", src)
+		}
+	}
+}
@@ -0,0 +1,599 @@
+package meow
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"io/ioutil"
+	"os"
+	"testing"
+	"unsafe"
+)
+
+// testData returns n bytes of deterministic test data from a linear
+// congruential generator, the same as used to produce goldenDigests.
+func testData(n int) []byte {
+	data := make([]byte, n)
+	x := uint32(12345)
+	for i := range data {
+		x = x*1664525 + 1013904223
+		data[i] = byte(x >> 24)
+	}
+	return data
+}
+
+// testSeed is the seed used for the seeded goldenDigests.
+func testSeed() [SeedSize]byte {
+	var seed [SeedSize]byte
+	for i := range seed {
+		seed[i] = byte(i*7 + 3)
+	}
+	return seed
+}
+
+// goldenDigests are hashes of testData(n) produced by the original cgo
+// wrapper around the upstream C implementation, as the bytes of the hash
+// in hex: hash with MeowDefaultSeed and seeded with testSeed().
+var goldenDigests = []struct {
+	n            int
+	hash, seeded string
+}{
+	{0, "45c059582aa07c655e26830355b5a775", "72a112cba3639e918c2e91077dd25ac3"},
+	{1, "2241c1edeea2472e1e226ef2cec330f6", "bb95fd430a69619df792dcfff90e0426"},
+	{15, "9253ff3a78c6bf63295c66974b2827ee", "59ca5aaaa197c9478cc2cd799c4c73d3"},
+	{16, "5338dd4b8761ca437d4bc2aa084fb4f0", "aa13e950ba564260da5717c1f4374da1"},
+	{17, "14fc30fa23e641b8108cad47d738ca17", "95d77f0b80116742be0c46dccdb133dc"},
+	{31, "946c39e037f0515adebb1b624434f0b4", "4f6e3b45ecfc0121d7ab5e3986044a28"},
+	{32, "751bf5d725c26106d5d127a833b115c9", "f7c1c34b6331b7e858f50a490e84c612"},
+	{33, "ac39bf6ae3b9bc63f143309858c5a9f0", "5e42852c82ae350d908e43cdfff88e8f"},
+	{63, "4058ec2ff2f185c215cf9ffbdb86cc1b", "a501e73905a3a5e30f291fe1bc4f664c"},
+	{64, "565974418d93df4baf4e3206775b0a53", "2ad94bb8d13b03597b257b44c1e9a840"},
+	{127, "6ad2b0aef03cab64b3716164a7760486", "c5f4891c63253e93d310e9a9ba13592e"},
+	{128, "799a7220b8407119cd05fc51a9984c58", "a7cadef9bb8354a52ede929d5ebf4d74"},
+	{129, "7ae1ad8059a782e2cf5b62fcd50f9bec", "119177b17b0403d2f8097eff7808d986"},
+	{255, "93abbe51fd97154abb6d9c8021ff3d2c", "14e5b61bbad6e269355a6a34795a69f7"},
+	{256, "3378546a0c5fce18f6fe51e50d56f432", "3c2c627fbfa018595e22775d20ea8cae"},
+	{257, "d4023cd9267e2cd4e16f50ac84552fdd", "d0006612bf968beb980a2e9e2f297b64"},
+	{511, "fcf4eedba859bc3367a76f5b5584b79b", "3ede18ce17cc513cc25a119afdf251ff"},
+	{512, "b01c87f6d6dd5ba6b2ff71dfaa352b53", "371d9b749c098350743a2274300fb232"},
+	{1023, "8b2c6599f4d86e8c3c31453f996af8ab", "4dc11267c9e5a1c6738ee5b86a6dd9d3"},
+	{1024, "d1916cb3c2b746814cea4ecb3b33be72", "894996e6acf893afc8c041020d8096ca"},
+	{1100, "4e0da20c3e18e1d6acf67f01fc5af0aa", "a2c44b25cb24797dd352e1e59e42621f"},
+	{4095, "18feccb53b6523d423b9f3a58e798aec", "23988b0e50cf6646a03cddf1b990f70b"},
+	{4096, "f7c685dac2242e0a8742b92ac92d952f", "9deec132d763f0d08d1b28e7b5f880b5"},
+	{4097, "3be9146a3da24507c5c17e2b328c6459", "52302f3728ced7c4cb167a92a41b4441"},
+	{65536, "69771d69fe7e57265e0e8c74a8724946", "bdb99c685eb7a7a701c98441b877d29c"},
+	{262143, "330f75b9f7a7e0225174a41a6f7e78d0", "87e6ac29074c50e2d3444647b3c7aa62"},
+	{262144, "a03390befeb2d016292594737448fe76", "c69c0bfb607349560f86b49b642024e8"},
+	{262145, "6baade90c3afc74bcfdb4232f77b869b", "f6bb0a13dc0922f14edc82a7f0c774a5"},
+	{1048576, "ad94a44cb80645e2544f968c6c3e34dd", "411dce082856d8a51e88cafaa83b6103"},
+	{1049353, "2f9c825b555707d2b9ab0583b1d55630", "9089dc03232d0f9509a2cd696889bb4b"},
+}
+
+// pageSize is the page size assumed by the residual loader.
+const pageSize = 4096
+
+// placements returns copies of data at the start and at the end of a page,
+// so the residual loader takes both of its paths.
+func placements(data []byte) map[string][]byte {
+	pages := (len(data) + pageSize - 1) / pageSize
+	atStart := pageAligned(pages)[:len(data)]
+	atEnd := pageAligned(pages)[pages*pageSize-len(data):]
+	copy(atStart, data)
+	copy(atEnd, data)
+	return map[string][]byte{"slice": data, "page start": atStart, "page end": atEnd}
+}
+
+// pageAligned returns a buffer of n pages that starts at a page boundary.
+func pageAligned(n int) []byte {
+	buf := make([]byte, (n+1)*pageSize)
+	start := int(-uintptr(unsafe.Pointer(&buf[0])) & (pageSize - 1))
+	return buf[start : start+n*pageSize]
+}
+
+func TestGoldenDigests(t *testing.T) {
+	seed := testSeed()
+	all := testData(goldenDigests[len(goldenDigests)-1].n)
+	for _, g := range goldenDigests {
+		for where, data := range placements(all[:g.n]) {
+			if got := hex.EncodeToString(Hash(data)); got != g.hash {
+				t.Errorf("Hash(%d bytes at %s) = %s, want %s", g.n, where, got, g.hash)
+			}
+			if got := hex.EncodeToString(HashSeed(seed, data)); got != g.seeded {
+				t.Errorf("HashSeed(%d bytes at %s) = %s, want %s", g.n, where, got, g.seeded)
+			}
+			// on amd64 the above use the asm, so check the portable port too
+			got := sumGeneric(&seed, data)
+			if hex.EncodeToString(got[:]) != g.seeded {
+				t.Errorf("sumGeneric(%d bytes at %s) = %x, want %s", g.n, where, got, g.seeded)
+			}
+		}
+	}
+}
+
+func TestGoldenStreaming(t *testing.T) {
+	seed := testSeed()
+	all := testData(goldenDigests[len(goldenDigests)-1].n)
+	splits := []int{1, 7, 255, 256, 257, 1000}
+	for _, g := range goldenDigests {
+		h := NewSeed(seed)
+		data := all[:g.n]
+		for i := 0; len(data) > 0; i++ {
+			k := splits[i%len(splits)]
+			if k > len(data) {
+				k = len(data)
+			}
+			h.Write(data[:k])
+			data = data[k:]
+		}
+		if got := hex.EncodeToString(h.Sum(nil)); got != g.seeded {
+			t.Errorf("NewSeed() of %d bytes = %s, want %s", g.n, got, g.seeded)
+		}
+	}
+}
+
+func TestGoldenPadded(t *testing.T) {
+	all := testData(goldenDigests[len(goldenDigests)-1].n + 16)
+	for _, g := range goldenDigests {
+		data := make([]byte, g.n, g.n+16)
+		copy(data, all)
+		got := HashPadded(data)
+		if hex.EncodeToString(got[:]) != g.hash {
+			t.Errorf("HashPadded(%d bytes) = %x, want %s", g.n, got, g.hash)
+		}
+	}
+}
+
+func TestGoldenFixed(t *testing.T) {
+	all := testData(64 + 100)
+	for _, g := range goldenDigests {
+		if g.n != 16 && g.n != 32 && g.n != 64 {
+			continue
+		}
+		// the golden input, then other keys of the same size
+		for off := 0; off < 100; off += 33 {
+			data := all[off : off+g.n]
+			var got Hash128
+			switch g.n {
+			case 16:
+				var key [16]byte
+				copy(key[:], data)
+				got = Hash16(key)
+			case 32:
+				var key [32]byte
+				copy(key[:], data)
+				got = Hash32(key)
+			case 64:
+				var key [64]byte
+				copy(key[:], data)
+				got = Hash64(key)
+			}
+			want := Hash(data)
+			if off == 0 && hex.EncodeToString(want) != g.hash {
+				t.Fatalf("Hash(%d bytes) = %x, want %s", g.n, want, g.hash)
+			}
+			if !bytes.Equal(got[:], want) {
+				t.Errorf("Hash%d(key at %d) = %x, want Hash() %x", g.n, off, got, want)
+			}
+		}
+	}
+}
+
+// expandSeedVectors are ExpandSeed() results as hex, for keys of the given
+// length: "", "a", "meow", "The quick brown fox jumps over the lazy dog",
+// and testData(300).
+var expandSeedVectors = []struct {
+	key  string
+	seed string
+}{
+	{"", "8908b4f6b956e9b46a5b1d9a8c237d0d1a1a0ea71a75e31de2ac67c19e33169769348d600feb279db6e736a85ab048642ec1852d2a4947007d6088d48d376d21f8b1d31867c32f520c6cbd2e4b28c615eb757c88ae83c60bbadf3e0a4bc547b439bad419e04f599e8c953a72100e361dde0ac1f9efe29fcd47ae5d59591e35c8"},
+	{"a", "14b1b00fe2722f12498e4529a1b9a283c3485f9aea4cf0311d0bbc15258a02f4c3302eff4d5a68f7e4e754d2cd37bd8d8590adda3aa9eec2bbbdf464fdfb1ddd00871ee71aa979be5fde44ea9d16e0decab57fd8831691e1bafb742065fa009f3521fcf475bf1f77fc4f1461563749b3640cbfd71eeb0568185afd5dddc103df"},
+	{"meow", "a28276c85503c50fd324733c2c09418f4156d8caae03710c728c8b2aa81e16b5c5b08525533caee27f2225e5c9d5bdc6e5aae2e031956123eac2808baf41412569c0dcf415cc5d9fa6025318c4368e301036ac0ee65d7c707120ec030820fa711ed9ee965b00b90572d1d55db5d0e2cd56cfc186ebca945655a2e81b5316cb55"},
+	{"The quick brown fox jumps over the lazy dog", "a6ab62a169c3da1d2b935e88f7cab82c7b90d44dfe9d9f6a01973885f68829a64a693abb67c5f802a4d15a0030a67b246d0bc97eda52c6bd3303a5c1deed3b9ff1aca8d2e429f0181eb9b035f87fefff6f3d111bf04754b7527f6e3163b81374d80c58f220565c1ea254cf4ece2ee6eee9c7f59cbe51274338dd29b473b0b58d"},
+	{string(testData(300)), "b69aa2aeef5165819d46a504fddd26489422568fb72f2735c8d701ae32e64e74d06a395c8fa7bd2097b96218bea0a0114a4ad36a97e33417f06b4e9454b38a34367d8addd980846561525853b40b0f1ef0f1e7047d44d06fef6ba64e5101b24e44d64556bfdd0b0fee660fd518414e0ceef6c3a157151f1866b62431a0f67474"},
+}
+
+func TestExpandSeed(t *testing.T) {
+	for _, v := range expandSeedVectors {
+		seed := ExpandSeed([]byte(v.key))
+		if got := hex.EncodeToString(seed[:]); got != v.seed {
+			t.Errorf("ExpandSeed(%d byte key) = %s, want %s", len(v.key), got, v.seed)
+		}
+
+		// The seed is the lanes that MeowHash() folds into its result, so
+		// folding them must give the hash of the input MeowExpandSeed()
+		// absorbs: the length, then the key (256/len)+2 times.
+		input := make([]byte, 8, 8+len(v.key)*(BlockSize+2))
+		binary.LittleEndian.PutUint64(input, uint64(len(v.key)))
+		if len(v.key) > 0 {
+			for i := BlockSize/len(v.key) + 2; i > 0; i-- {
+				input = append(input, v.key...)
+			}
+		}
+		var l lanes
+		l.load(&seed)
+		x0 := paddq(pxor(paddq(l[0], l[2]), paddq(l[1], l[3])), pxor(paddq(l[4], l[6]), paddq(l[5], l[7])))
+		var folded [HashSize]byte
+		store(folded[:], x0)
+		if want := Hash(input); !bytes.Equal(folded[:], want) {
+			t.Errorf("ExpandSeed(%d byte key) folds to %x, want Hash() of its input %x", len(v.key), folded, want)
+		}
+	}
+}
+
+// marshalable is a hash whose state can be saved and restored.
+type marshalable interface {
+	hash.Hash
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+func TestMarshalResume(t *testing.T) {
+	data := testData(3 * BlockSize)
+	seed := testSeed()
+	news := map[string]func() hash.Hash{
+		"New":     New,
+		"NewSeed": func() hash.Hash { return NewSeed(seed) },
+		"New64":   func() hash.Hash { return New64() },
+		"New32":   func() hash.Hash { return New32() },
+	}
+	for name, newHash := range news {
+		whole := newHash()
+		whole.Write(data)
+		want := whole.Sum(nil)
+		for _, split := range []int{0, 1, 15, BlockSize - 1, BlockSize, BlockSize + 1, 2*BlockSize + 100, len(data)} {
+			h := newHash()
+			h.Write(data[:split])
+			state, err := h.(marshalable).MarshalBinary()
+			if err != nil {
+				t.Fatalf("%s: MarshalBinary: %v", name, err)
+			}
+			resumed := newHash()
+			if err := resumed.(marshalable).UnmarshalBinary(state); err != nil {
+				t.Fatalf("%s: UnmarshalBinary after %d bytes: %v", name, split, err)
+			}
+			resumed.Write(data[split:])
+			if got := resumed.Sum(nil); !bytes.Equal(got, want) {
+				t.Errorf("%s: resumed after %d bytes = %x, want %x", name, split, got, want)
+			}
+		}
+	}
+}
+
+func TestUnmarshalRejects(t *testing.T) {
+	h := New()
+	h.Write(testData(300))
+	state, _ := h.(marshalable).MarshalBinary()
+
+	wrongVersion := append([]byte(nil), state...)
+	wrongVersion[len(stateMagic)+1]++
+	wrongFormat := append([]byte(nil), state...)
+	wrongFormat[len(stateMagic)]++
+
+	tests := []struct {
+		name  string
+		h     hash.Hash
+		state []byte
+		err   error
+	}{
+		{"New64 from New", New64(), state, errStateInvalid},
+		{"New32 from New", New32(), state, errStateInvalid},
+		{"wrong seed", NewSeed(testSeed()), state, errStateSeed},
+		{"wrong Version", New(), wrongVersion, errStateVersion},
+		{"wrong format", New(), wrongFormat, errStateFormat},
+		{"truncated", New(), state[:len(state)-1], errStateInvalid},
+	}
+	for _, tt := range tests {
+		if err := tt.h.(marshalable).UnmarshalBinary(tt.state); err != tt.err {
+			t.Errorf("%s: UnmarshalBinary() = %v, want %v", tt.name, err, tt.err)
+		}
+	}
+
+	h64 := New64()
+	state64, _ := h64.(marshalable).MarshalBinary()
+	if err := New().(marshalable).UnmarshalBinary(state64); err != errStateInvalid {
+		t.Errorf("New from New64: UnmarshalBinary() = %v, want %v", err, errStateInvalid)
+	}
+	if err := New32().(marshalable).UnmarshalBinary(state64); err != errStateInvalid {
+		t.Errorf("New32 from New64: UnmarshalBinary() = %v, want %v", err, errStateInvalid)
+	}
+}
+
+// batchInputs returns inputs of mixed lengths: runs of short inputs that
+// are hashed in pairs, long inputs between them, and empty inputs.
+func batchInputs() [][]byte {
+	data := testData(5000)
+	lengths := []int{0, 1, 15, 16, 17, 31, 32, 33, 100, 255, 256, 257, 3, 1000, 0, 0, 64, 4097, 200, 7, 128}
+	var inputs [][]byte
+	off := 0
+	for _, n := range lengths {
+		inputs = append(inputs, data[off:off+n])
+		off = (off + 37) % 100
+	}
+	return inputs
+}
+
+func TestHashBatch(t *testing.T) {
+	seed := testSeed()
+	inputs := batchInputs()
+	// every prefix, so each input is hashed both in and out of a pair
+	for n := 0; n <= len(inputs); n++ {
+		out := make([][HashSize]byte, n)
+		HashBatch(seed, inputs[:n], out)
+		generic := make([][HashSize]byte, n)
+		sumBatchGeneric(&seed, inputs[:n], generic)
+		for i, in := range inputs[:n] {
+			want := HashSeed(seed, in)
+			if !bytes.Equal(out[i][:], want) {
+				t.Errorf("HashBatch of %d inputs: input %d (%d bytes) = %x, want %x", n, i, len(in), out[i], want)
+			}
+			if !bytes.Equal(generic[i][:], want) {
+				t.Errorf("sumBatchGeneric of %d inputs: input %d (%d bytes) = %x, want %x", n, i, len(in), generic[i], want)
+			}
+		}
+	}
+}
+
+// benchmarkKeys are 1000 inputs of 32 bytes, like short map keys.
+func benchmarkKeys() [][]byte {
+	data := testData(1000 * 32)
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = data[i*32 : i*32+32]
+	}
+	return keys
+}
+
+func BenchmarkHashBatch(b *testing.B) {
+	keys := benchmarkKeys()
+	out := make([][HashSize]byte, len(keys))
+	b.SetBytes(int64(len(keys) * 32))
+	for i := 0; i < b.N; i++ {
+		HashBatch(MeowDefaultSeed, keys, out)
+	}
+}
+
+func BenchmarkHashLoop(b *testing.B) {
+	keys := benchmarkKeys()
+	b.SetBytes(int64(len(keys) * 32))
+	for i := 0; i < b.N; i++ {
+		for _, k := range keys {
+			Hash(k)
+		}
+	}
+}
+
+// testNonce is the nonce bytes 0x00, 0x01, ... 0x0f.
+func testNonce() [16]byte {
+	var nonce [16]byte
+	for i := range nonce {
+		nonce[i] = byte(i)
+	}
+	return nonce
+}
+
+func TestHashNonceVectors(t *testing.T) {
+	vectors := []struct {
+		data, hash string
+	}{
+		{"", "9A4A4611-5C4E9664-659D1F97-1A7B81C7"},
+		{"meow", "7CE0AA26-BF9288F1-09852B10-6E379EB6"},
+		{"The quick brown fox jumps over the lazy dog", "8231A40E-645DF008-F46B9B35-882A14FE"},
+	}
+	for _, v := range vectors {
+		if got := HashNonce(MeowDefaultSeed, testNonce(), []byte(v.data)); got.String() != v.hash {
+			t.Errorf("HashNonce(%q) = %v, want %s", v.data, got, v.hash)
+		}
+	}
+}
+
+// hashNonceGeneric is HashNonce() using the portable port.
+func hashNonceGeneric(seed [SeedSize]byte, nonce [16]byte, data []byte) [HashSize]byte {
+	full := len(data) &^ (BlockSize - 1)
+	absorbBlocksGeneric(&seed, data[:full])
+	var buf [BlockSize]byte
+	copy(buf[:], data[full:])
+	return finishGeneric(&seed, uint64(len(data)), &buf, &nonce)
+}
+
+func TestHashNonce(t *testing.T) {
+	seed := testSeed()
+	all := testData(goldenDigests[len(goldenDigests)-1].n)
+	for _, g := range goldenDigests {
+		data := all[:g.n]
+		if got := HashNonce(seed, [16]byte{}, data); hex.EncodeToString(got[:]) != g.seeded {
+			t.Errorf("HashNonce(%d bytes) with zero nonce = %x, want HashSeed() %s", g.n, got, g.seeded)
+		}
+		got := HashNonce(seed, testNonce(), data)
+		if want := hashNonceGeneric(seed, testNonce(), data); got != want {
+			t.Errorf("HashNonce(%d bytes) = %x, portable port gives %x", g.n, got, want)
+		}
+		if hex.EncodeToString(got[:]) == g.seeded {
+			t.Errorf("HashNonce(%d bytes) with a nonce = HashSeed()", g.n)
+		}
+	}
+}
+
+func TestNoAllocs(t *testing.T) {
+	data := testData(1000)
+	s := string(data)
+	var dst [HashSize]byte
+	tests := map[string]func(){
+		"HashString": func() { HashString(s) },
+		"HashInto":   func() { HashInto(&dst, data) },
+		"Sum128":     func() { Sum128(data) },
+	}
+	for name, f := range tests {
+		if n := testing.AllocsPerRun(100, f); n != 0 {
+			t.Errorf("%s allocates %v times, want 0", name, n)
+		}
+	}
+
+	want := Hash(data)
+	if got := HashString(s); !bytes.Equal(got[:], want) {
+		t.Errorf("HashString() = %x, want %x", got, want)
+	}
+	HashInto(&dst, data)
+	if !bytes.Equal(dst[:], want) {
+		t.Errorf("HashInto() = %x, want %x", dst, want)
+	}
+	lo, hi := Sum128(data)
+	if lo != binary.LittleEndian.Uint64(want[:8]) || hi != binary.LittleEndian.Uint64(want[8:]) {
+		t.Errorf("Sum128() = %x, %x, want %x", lo, hi, want)
+	}
+}
+
+func TestEmptyInput(t *testing.T) {
+	const want = "75A7B555-0383265E-657CA02A-5859C045"
+
+	f, err := ioutil.TempFile("", "meow-empty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	fromFile := func(hashFile func(string) (Hash128, int64, error)) func() []byte {
+		return func() []byte {
+			h, n, err := hashFile(f.Name())
+			if err != nil || n != 0 {
+				t.Errorf("hashing empty file: %d bytes, %v", n, err)
+			}
+			return h[:]
+		}
+	}
+	tests := []struct {
+		name string
+		hash func() []byte
+	}{
+		{"Hash(nil)", func() []byte { return Hash(nil) }},
+		{"Hash([]byte{})", func() []byte { return Hash([]byte{}) }},
+		{"HashSeed", func() []byte { return HashSeed(MeowDefaultSeed, nil) }},
+		{"New().Sum", func() []byte { return New().Sum(nil) }},
+		{"HashString", func() []byte { h := HashString(""); return h[:] }},
+		{"HashPadded", func() []byte { h := HashPadded(nil); return h[:] }},
+		{"HashVec()", func() []byte { h := HashVec(MeowDefaultSeed); return h[:] }},
+		{"HashVec(nil)", func() []byte { h := HashVec(MeowDefaultSeed, nil); return h[:] }},
+		{"HashBatch", func() []byte {
+			out := make([][HashSize]byte, 1)
+			HashBatch(MeowDefaultSeed, [][]byte{{}}, out)
+			return out[0][:]
+		}},
+		{"HashReader", func() []byte {
+			h, n, err := HashReader(bytes.NewReader(nil))
+			if err != nil || n != 0 {
+				t.Errorf("HashReader: %d bytes, %v", n, err)
+			}
+			return h[:]
+		}},
+		{"HashFile", fromFile(HashFile)},
+		{"HashFileMapped", fromFile(HashFileMapped)},
+	}
+	for _, tt := range tests {
+		if got := String(tt.hash()); got != want {
+			t.Errorf("%s = %s, want %s", tt.name, got, want)
+		}
+	}
+}
+
+func TestSumAppends(t *testing.T) {
+	data := testData(300)
+	want := Hash(data)
+	prefix := []byte("prefix")
+
+	h := New()
+	h.Write(data)
+	if got := h.Sum(append([]byte(nil), prefix...)); !bytes.Equal(got, append(prefix, want...)) {
+		t.Errorf("New().Sum(prefix) = %x, want prefix then %x", got, want)
+	}
+
+	lo := Sum64(data)
+	if lo != binary.LittleEndian.Uint64(want[:8]) {
+		t.Errorf("Sum64() = %x, want the first 8 bytes of %x", lo, want)
+	}
+	h64 := New64()
+	h64.Write(data)
+	if got := h64.Sum64(); got != lo {
+		t.Errorf("New64().Sum64() = %x, want Sum64() %x", got, lo)
+	}
+	var be64 [8]byte
+	binary.BigEndian.PutUint64(be64[:], lo)
+	if got := h64.Sum(append([]byte(nil), prefix...)); !bytes.Equal(got, append(prefix, be64[:]...)) {
+		t.Errorf("New64().Sum(prefix) = %x, want prefix then %x", got, be64)
+	}
+
+	h32 := New32()
+	h32.Write(data)
+	if got := h32.Sum32(); got != uint32(lo) {
+		t.Errorf("New32().Sum32() = %x, want low 32 bits of Sum64() %x", got, uint32(lo))
+	}
+	var be32 [4]byte
+	binary.BigEndian.PutUint32(be32[:], uint32(lo))
+	if got := h32.Sum(append([]byte(nil), prefix...)); !bytes.Equal(got, append(prefix, be32[:]...)) {
+		t.Errorf("New32().Sum(prefix) = %x, want prefix then %x", got, be32)
+	}
+}
+
+// vecParts returns data cut at cuts, and the parts joined with and without
+// 8-byte little endian length prefixes.
+func vecParts(data []byte, cuts []int) (parts [][]byte, joined, prefixed []byte) {
+	prev := 0
+	for _, c := range cuts {
+		parts = append(parts, data[prev:c])
+		var length [8]byte
+		binary.LittleEndian.PutUint64(length[:], uint64(c-prev))
+		prefixed = append(append(prefixed, length[:]...), data[prev:c]...)
+		prev = c
+	}
+	return parts, data[:prev], prefixed
+}
+
+func TestHashVec(t *testing.T) {
+	seed := testSeed()
+	data := testData(5000)
+	for _, cuts := range [][]int{
+		{},
+		{0},
+		{5, 5, 300},
+		{1, 255, 256, 257, 600, 1100},
+		{100, 612, 1000, 5000},
+	} {
+		parts, joined, prefixed := vecParts(data, cuts)
+		if got := HashVec(seed, parts...); !bytes.Equal(got[:], HashSeed(seed, joined)) {
+			t.Errorf("HashVec(cut at %v) = %x, want HashSeed() of the joined parts", cuts, got)
+		}
+		if got := HashVecPrefixed(seed, parts...); !bytes.Equal(got[:], HashSeed(seed, prefixed)) {
+			t.Errorf("HashVecPrefixed(cut at %v) = %x, want HashSeed() of the prefixed parts", cuts, got)
+		}
+		f := func() { HashVec(seed, parts...); HashVecPrefixed(seed, parts...) }
+		if n := testing.AllocsPerRun(10, f); n != 0 {
+			t.Errorf("HashVec and HashVecPrefixed(cut at %v) allocate %v times, want 0", cuts, n)
+		}
+	}
+}
+
+func TestHashVecPrefixedVectors(t *testing.T) {
+	// the same vectors as the HashVecPrefixed doc comment
+	vectors := []struct {
+		parts []string
+		hash  string
+	}{
+		{[]string{"ab", "c"}, "C8C1B942-7B64E2CD-5D073693-BA82961C"},
+		{[]string{"a", "bc"}, "3B14F709-E5D81A2F-EAECEA31-CA21392B"},
+		{[]string{"", "meow"}, "34986F3D-22689DA3-8165887B-3C3A8983"},
+	}
+	for _, v := range vectors {
+		var parts [][]byte
+		for _, p := range v.parts {
+			parts = append(parts, []byte(p))
+		}
+		if got := HashVecPrefixed(MeowDefaultSeed, parts...); got.String() != v.hash {
+			t.Errorf("HashVecPrefixed(%q) = %v, want %s", v.parts, got, v.hash)
+		}
+	}
+	if HashVec(MeowDefaultSeed, []byte("ab"), []byte("c")) != HashVec(MeowDefaultSeed, []byte("a"), []byte("bc")) {
+		t.Errorf("HashVec() depends on where the parts are cut")
+	}
+}
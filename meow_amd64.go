@@ -0,0 +1,130 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+package meow
+
+// useAsm is whether the CPU supports everything meow_amd64.s uses: the
+// VEX encoded AES-NI and SSE instructions need AES, SSSE3, SSE4.1 and AVX,
+// and the OS must save the AVX state. Without them the assembly would die
+// with SIGILL, so the portable version is used instead.
+var useAsm = hasAESAVX()
+
+// implementation is reported by Implementation().
+var implementation = "generic"
+
+func init() {
+	if useAsm {
+		implementation = "aesni"
+	}
+}
+
+// hasAESAVX checks CPUID and XGETBV for the features useAsm needs.
+func hasAESAVX() bool {
+	const (
+		ssse3   = 1 << 9
+		sse41   = 1 << 19
+		aes     = 1 << 25
+		osxsave = 1 << 27
+		avx     = 1 << 28
+	)
+	maxID, _, _, _ := cpuid(0, 0)
+	if maxID < 1 {
+		return false
+	}
+	_, _, ecx, _ := cpuid(1, 0)
+	const want = ssse3 | sse41 | aes | osxsave | avx
+	if ecx&want != want {
+		return false
+	}
+	// the OS must save the XMM (bit 1) and YMM (bit 2) state
+	xcr0, _ := xgetbv()
+	return xcr0&6 == 6
+}
+
+// sum hashes data using seed with the AES-NI implementation of MeowHash.
+func sum(seed *[SeedSize]byte, data []byte) [HashSize]byte {
+	if !useAsm {
+		return sumGeneric(seed, data)
+	}
+	var b [HashSize]byte
+	hashAsm(seed, data, &b)
+	return b
+}
+
+// sumPadded hashes data using seed like sum, but requires that 16 bytes can
+// be read at len(data) rounded down to a multiple of 16.
+func sumPadded(seed *[SeedSize]byte, data []byte) [HashSize]byte {
+	if !useAsm {
+		return sumGeneric(seed, data)
+	}
+	var b [HashSize]byte
+	hashPaddedAsm(seed, data, &b)
+	return b
+}
+
+// sumBatch hashes each of inputs using seed into out, which must be at
+// least as long as inputs.
+func sumBatch(seed *[SeedSize]byte, inputs [][]byte, out [][HashSize]byte) {
+	if !useAsm {
+		sumBatchGeneric(seed, inputs, out)
+		return
+	}
+	if len(inputs) == 0 {
+		return
+	}
+	hashBatchAsm(seed, inputs, &out[:len(inputs)][0])
+}
+
+// absorbBlocks mixes the full BlockSize blocks of data into lanes.
+// len(data) must be a multiple of BlockSize.
+func absorbBlocks(lanes *[SeedSize]byte, data []byte) {
+	if len(data) < BlockSize {
+		return
+	}
+	if !useAsm {
+		absorbBlocksGeneric(lanes, data)
+		return
+	}
+	absorbBlocksAsm(lanes, data)
+}
+
+// finish mixes the residual in buf and the total length into lanes and
+// folds them down to the final hash. buf holds the last total%BlockSize
+// bytes of input. The lanes are left in their post-mix state, which is
+// what MeowExpandSeed() uses as a seed.
+func finish(lanes *[SeedSize]byte, total uint64, buf *[BlockSize]byte) [HashSize]byte {
+	return finishNonce(lanes, total, buf, nil)
+}
+
+// finishNonce is finish with nonce, if not nil, in the 128-bit nonce slot.
+func finishNonce(lanes *[SeedSize]byte, total uint64, buf *[BlockSize]byte, nonce *[16]byte) [HashSize]byte {
+	if !useAsm {
+		return finishGeneric(lanes, total, buf, nonce)
+	}
+	var b [HashSize]byte
+	finishAsm(lanes, total, buf, nonce, &b)
+	return b
+}
+
+// Implemented in meow_amd64.s.
+
+//go:noescape
+func hashAsm(seed *[SeedSize]byte, data []byte, out *[HashSize]byte)
+
+//go:noescape
+func hashPaddedAsm(seed *[SeedSize]byte, data []byte, out *[HashSize]byte)
+
+//go:noescape
+func hashBatchAsm(seed *[SeedSize]byte, inputs [][]byte, out *[HashSize]byte)
+
+//go:noescape
+func absorbBlocksAsm(lanes *[SeedSize]byte, data []byte)
+
+//go:noescape
+func finishAsm(lanes *[SeedSize]byte, total uint64, buf *[BlockSize]byte, nonce *[16]byte, out *[HashSize]byte)
+
+// Implemented in cpu_amd64.s.
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax, edx uint32)
@@ -0,0 +1,632 @@
+//go:build amd64 && !purego
+// +build amd64,!purego
+
+// ========================================================================
+//
+// Meow - A Fast Non-cryptographic Hash
+// (C) Copyright 2018-2019 by Molly Rocket, Inc. (https://mollyrocket.com)
+//
+// See https://mollyrocket.com/meowhash for details.
+//
+// ========================================================================
+//
+// zlib License
+//
+// (C) Copyright 2018-2019 Molly Rocket, Inc.
+//
+// This software is provided 'as-is', without any express or implied
+// warranty.  In no event will the authors be held liable for any damages
+// arising from the use of this software.
+//
+// Permission is granted to anyone to use this software for any purpose,
+// including commercial applications, and to alter it and redistribute it
+// freely, subject to the following restrictions:
+//
+// 1. The origin of this software must not be misrepresented; you must not
+//    claim that you wrote the original software. If you use this software
+//    in a product, an acknowledgment in the product documentation would be
+//    appreciated but is not required.
+// 2. Altered source versions must be plainly marked as such, and must not be
+//    misrepresented as being the original software.
+// 3. This notice may not be removed or altered from any source distribution.
+//
+// ========================================================================
+//
+// FAQ
+//
+// Q: What is it?
+//
+// A: Meow is a 128-bit Level 3 hash taking 128 bytes of seed.  It operates
+//    at very high speeds on x64 processors, and potentially other processors
+//    that provide accelerated AES instructions.
+//
+// Q: What is it GOOD for?
+//
+// A: Quickly hashing any amount of data for comparison purposes such as
+//    block deduplication or change detection.  It is fast on all buffer
+//    sizes, and can generally be used anywhere you need fast Level 3
+//    hashing without worrying about how big or small the inputs tend to be.
+//
+//    However, substantial speed improvements could be made over Meow
+//    if you either a) know you are always hashing an exact, small number of bytes,
+//    or b) can always supply a small number of bytes in a buffer padded to some
+//    fixed multiple of 16.
+//
+// Q: What is it BAD for?
+//
+// A: Anything requiring Level 4 or Level 5 security guarantees (see
+//    http://nohatcoder.dk/2019-05-19-1.html#level3).  Also, note that
+//    Meow is a new hash and has not had the extensive community
+//    cryptanalysis necessary to ensure that it is not breakable down to
+//    a lower level of hash, so you must do your due diligence in
+//    deciding when and where to use Meow instead of a slower but
+//    more extensively studied existing hash.  We have tried to design
+//    it to provide Level 3 security, but the possibility of the hash
+//    being broken in the future always exists.
+//
+// Q: Why is it called the "Meow hash"?
+//
+// A: It is named after a character in Meow the Infinite
+//    (https://meowtheinfinite.com)
+//
+// Q: Who wrote it?
+//
+// A: The final Meow Hash was created as a collaboration between
+//    JACOB CHRISTIAN MUNCH-ANDERSEN (https://twitter.com/nohatcoder) and
+//    CASEY MURATORI (https://caseymuratori.com).  Casey wrote the original
+//    implementation for use in processing large-footprint assets for the
+//    game 1935 (https://molly1935.com).  Jacob was the first to analyze
+//    that implementation and determine the adversarial bit strength, which
+//    was weaker than they would have liked.
+//
+//    Following that, the two collaborated to figure out how the hash
+//    could be strengthened without reducing Meow's 16 bytes/cycle
+//    maximum theoretical throughput.  Jacob created the hash candidates
+//    and Casey did the performance validation.  After a long and
+//    exhaustive effort, Jacob found the unaligned aes/add/xor formulation
+//    that forms the current Meow hash core.
+//
+//    A number of valuable additions to Meow Hash were also contributed
+//    by other great folks along the way:
+//
+//    JEFF ROBERTS (https://radgametools.com) provided a super slick
+//    way to handle the residual end-of-buffer bytes that dramatically
+//    improved Meow's small hash performance.
+//
+//    MARTINS MOZEIKO (https://matrins.ninja) ported Meow to ARM and
+//    ANSI-C, and added the proper preprocessor dressing for clean
+//    compilation on a variety of compiler configurations.
+//
+//    FABIAN GIESEN (https://fgiesen.wordpress.com) analyzed many
+//    performance oddities that came up during development, and
+//    helped get the benchmarking working properly across a number
+//    of platforms.
+//
+//    ARAS PRANCKEVICIUS (https://aras-p.info) provided the allocation
+//    shim for compilation on Mac OS X.
+//
+// ========================================================================
+//
+// This file is a Go assembly port of MeowHash() and the streaming
+// construction (MeowAbsorbBlocks, MeowEnd) from meow_hash_x64_aesni.h,
+// version 0.5/calico. Altered from the original C: it is written for the Go
+// assembler and uses the VEX (AVX) forms of the SSE and AES-NI instructions,
+// just as the C compiled with -mavx -maes did. It is called directly from Go,
+// without cgo.
+
+
+#include "textflag.h"
+
+// MeowShiftAdjust
+DATA shiftAdjust<>+0x00(SB)/8, $0x0706050403020100
+DATA shiftAdjust<>+0x08(SB)/8, $0x0f0e0d0c0b0a0908
+DATA shiftAdjust<>+0x10(SB)/8, $0x0706050403020100
+DATA shiftAdjust<>+0x18(SB)/8, $0x0f0e0d0c0b0a0908
+GLOBL shiftAdjust<>(SB), (NOPTR+RODATA), $32
+
+// MeowMaskLen
+DATA maskLen<>+0x00(SB)/8, $0xffffffffffffffff
+DATA maskLen<>+0x08(SB)/8, $0xffffffffffffffff
+DATA maskLen<>+0x10(SB)/8, $0x0000000000000000
+DATA maskLen<>+0x18(SB)/8, $0x0000000000000000
+GLOBL maskLen<>(SB), (NOPTR+RODATA), $32
+
+#define HashSize 16
+#define BlockSize 256
+
+#define MEOW_PAGESIZE 4096
+#define MEOW_PREFETCH 4096
+#define MEOW_PREFETCH_LIMIT 0x3ff
+
+#define MEOW_MIX_REG(r1, r2, r3, r4, r5, i1, i2, i3, i4) \
+	VAESDEC r2, r1, r1; \
+	VPADDQ  i1, r3, r3; \
+	VPXOR   i2, r2, r2; \
+	VAESDEC r4, r2, r2; \
+	VPADDQ  i3, r5, r5; \
+	VPXOR   i4, r4, r4
+
+// MEOW_MIX_AT reads 32 bytes at off(ptr).
+#define MEOW_MIX_AT(r1, r2, r3, r4, r5, off, ptr) \
+	MEOW_MIX_REG(r1, r2, r3, r4, r5, (off+15)(ptr), (off+0)(ptr), (off+1)(ptr), (off+16)(ptr))
+
+// MEOW_MIX reads 32 bytes at off(SI).
+#define MEOW_MIX(r1, r2, r3, r4, r5, off) \
+	MEOW_MIX_AT(r1, r2, r3, r4, r5, off, SI)
+
+#define MEOW_SHUFFLE(r1, r2, r3, r4, r5, r6) \
+	VAESDEC r4, r1, r1; \
+	VPADDQ  r5, r2, r2; \
+	VPXOR   r6, r4, r4; \
+	VAESDEC r2, r4, r4; \
+	VPADDQ  r6, r5, r5; \
+	VPXOR   r3, r2, r2
+
+// LOAD_LANES seeds the eight hash registers X0-X7 from 128 bytes at (R).
+#define LOAD_LANES(R) \
+	VMOVDQU 0x00(R), X0; \
+	VMOVDQU 0x10(R), X1; \
+	VMOVDQU 0x20(R), X2; \
+	VMOVDQU 0x30(R), X3; \
+	VMOVDQU 0x40(R), X4; \
+	VMOVDQU 0x50(R), X5; \
+	VMOVDQU 0x60(R), X6; \
+	VMOVDQU 0x70(R), X7
+
+// STORE_LANES stores X0-X7 to 128 bytes at (R).
+#define STORE_LANES(R) \
+	VMOVDQU X0, 0x00(R); \
+	VMOVDQU X1, 0x10(R); \
+	VMOVDQU X2, 0x20(R); \
+	VMOVDQU X3, 0x30(R); \
+	VMOVDQU X4, 0x40(R); \
+	VMOVDQU X5, 0x50(R); \
+	VMOVDQU X6, 0x60(R); \
+	VMOVDQU X7, 0x70(R)
+
+#define MEOW_BLOCK \
+	MEOW_MIX(X0, X4, X6, X1, X2, 0x00); \
+	MEOW_MIX(X1, X5, X7, X2, X3, 0x20); \
+	MEOW_MIX(X2, X6, X0, X3, X4, 0x40); \
+	MEOW_MIX(X3, X7, X1, X4, X5, 0x60); \
+	MEOW_MIX(X4, X0, X2, X5, X6, 0x80); \
+	MEOW_MIX(X5, X1, X3, X6, X7, 0xa0); \
+	MEOW_MIX(X6, X2, X4, X7, X0, 0xc0); \
+	MEOW_MIX(X7, X3, X5, X0, X1, 0xe0); \
+	ADDQ $0x100, SI
+
+// MEOW_BLOCKS hashes BX full 256-byte blocks at SI, advancing SI past them.
+//
+// For large input, modern Intel x64's can't hit full speed without
+// prefetching, and for small input they can't hit full speed _with_
+// prefetching (because of port pressure), so there are two loops.
+#define MEOW_BLOCKS \
+	CMPQ BX, $MEOW_PREFETCH_LIMIT; \
+	JLS  small; \
+prefetch: \
+	PREFETCHT0 (MEOW_PREFETCH+0x00)(SI); \
+	PREFETCHT0 (MEOW_PREFETCH+0x40)(SI); \
+	PREFETCHT0 (MEOW_PREFETCH+0x80)(SI); \
+	PREFETCHT0 (MEOW_PREFETCH+0xc0)(SI); \
+	MEOW_BLOCK; \
+	DECQ BX; \
+	JNZ  prefetch; \
+	JMP  blocksdone; \
+small: \
+	TESTQ BX, BX; \
+	JZ    blocksdone; \
+smallloop: \
+	MEOW_BLOCK; \
+	DECQ BX; \
+	JNZ  smallloop; \
+blocksdone:
+
+// MEOW_RESIDUAL_PADDED loads the less-than-32-byte residual of the DX
+// bytes ending in the partial block at SI into X9 and X11. It is for when
+// 16 bytes at Last can always be read, so unlike residualCore it doesn't
+// need MeowShiftAdjust or the page boundary check. Clobbers AX and R8-R10.
+#define MEOW_RESIDUAL_PADDED \
+	VPXOR X9, X9, X9; \
+	VPXOR X11, X11, X11; \
+	MOVQ  DX, R8; \
+	ANDQ  $0xf0, R8; \
+	ADDQ  SI, R8; \
+	MOVQ  DX, AX; \
+	ANDQ  $0xf, AX; \
+	JZ    paddedaligned; \
+	LEAQ    maskLen<>(SB), R9; \
+	MOVQ    $0x10, R10; \
+	SUBQ    AX, R10; \
+	VMOVDQU (R9)(R10*1), X8; \
+	VMOVDQU (R8), X9; \
+	VPAND   X8, X9, X9; \
+paddedaligned: \
+	TESTQ   $0x10, DX; \
+	JZ      paddeddone; \
+	VMOVDQU X9, X11; \
+	VMOVDQU -0x10(R8), X9; \
+paddeddone:
+
+// MEOW_INJEST constructs the residual and length injests in X8-X15 from
+// the residual in X9 and X11 and the length in DX.
+//
+// There is room for a 128-bit nonce (X13) and a 64-bit nonce (the high
+// half of X15) here. Upstream leaves them zero'd so as not to confuse
+// people about how to use them or what security implications they had;
+// only HashNonce() fills in X13.
+#define MEOW_INJEST \
+	VPALIGNR $15, X11, X9, X8; \
+	VPALIGNR $1, X11, X9, X10; \
+	VPXOR    X12, X12, X12; \
+	VPXOR    X13, X13, X13; \
+	VPXOR    X14, X14, X14; \
+	VMOVQ    DX, X15; \
+	VPALIGNR $15, X15, X12, X12; \
+	VPALIGNR $1, X15, X14, X14
+
+// MEOW_LANES mixes the residual and length injests in X8-X15 into X0-X7,
+// then hashes the remaining (DX >> 5) & 7 full 32-byte lanes at SI. To
+// maintain the mix-down pattern, the residual is always mixed, even if it
+// was empty.
+#define MEOW_LANES \
+	MEOW_MIX_REG(X0, X4, X6, X1, X2, X8, X9, X10, X11); \
+	MEOW_MIX_REG(X1, X5, X7, X2, X3, X12, X13, X14, X15); \
+	MOVQ DX, BX; \
+	SHRQ $5, BX; \
+	ANDQ $7, BX; \
+	JZ   lanesdone; \
+	MEOW_MIX(X2, X6, X0, X3, X4, 0x00); \
+	DECQ BX; \
+	JZ   lanesdone; \
+	MEOW_MIX(X3, X7, X1, X4, X5, 0x20); \
+	DECQ BX; \
+	JZ   lanesdone; \
+	MEOW_MIX(X4, X0, X2, X5, X6, 0x40); \
+	DECQ BX; \
+	JZ   lanesdone; \
+	MEOW_MIX(X5, X1, X3, X6, X7, 0x60); \
+	DECQ BX; \
+	JZ   lanesdone; \
+	MEOW_MIX(X6, X2, X4, X7, X0, 0x80); \
+	DECQ BX; \
+	JZ   lanesdone; \
+	MEOW_MIX(X7, X3, X5, X0, X1, 0xa0); \
+	DECQ BX; \
+	JZ   lanesdone; \
+	MEOW_MIX(X0, X4, X6, X1, X2, 0xc0); \
+lanesdone:
+
+// MEOW_MIXDOWN mixes the eight lanes a-h down, in the MEOW_SHUFFLE order.
+#define MEOW_MIXDOWN(a, b, c, d, e, f, g, h) \
+	MEOW_SHUFFLE(a, b, c, e, f, g); \
+	MEOW_SHUFFLE(b, c, d, f, g, h); \
+	MEOW_SHUFFLE(c, d, e, g, h, a); \
+	MEOW_SHUFFLE(d, e, f, h, a, b); \
+	MEOW_SHUFFLE(e, f, g, a, b, c); \
+	MEOW_SHUFFLE(f, g, h, b, c, d); \
+	MEOW_SHUFFLE(g, h, a, c, d, e); \
+	MEOW_SHUFFLE(h, a, b, d, e, f); \
+	MEOW_SHUFFLE(a, b, c, e, f, g); \
+	MEOW_SHUFFLE(b, c, d, f, g, h); \
+	MEOW_SHUFFLE(c, d, e, g, h, a); \
+	MEOW_SHUFFLE(d, e, f, h, a, b)
+
+// MEOW_FOLD folds the post-mix lanes X0-X7 into X8, leaving X0-X7 alone.
+#define MEOW_FOLD \
+	VPADDQ X2, X0, X8; \
+	VPADDQ X3, X1, X9; \
+	VPADDQ X6, X4, X10; \
+	VPADDQ X7, X5, X11; \
+	VPXOR  X9, X8, X8; \
+	VPXOR  X11, X10, X10; \
+	VPADDQ X10, X8, X8
+
+// MEOW_FOLD_INPLACE folds the post-mix lanes a-h into a.
+#define MEOW_FOLD_INPLACE(a, b, c, d, e, f, g, h) \
+	VPADDQ c, a, a; \
+	VPADDQ d, b, b; \
+	VPADDQ g, e, e; \
+	VPADDQ h, f, f; \
+	VPXOR  b, a, a; \
+	VPXOR  f, e, e; \
+	VPADDQ e, a, a
+
+// residualCore loads the less-than-32-byte residual of the DX bytes at DI
+// and constructs the residual and length injests in X8-X15. It never reads
+// past the end of the page holding the last byte. It clobbers AX and R8-R11.
+TEXT residualCore<>(SB), NOSPLIT, $0
+	VPXOR X9, X9, X9
+	VPXOR X11, X11, X11
+
+	// first, load the part that is _not_ 16-byte aligned
+	MOVQ DX, R8
+	ANDQ $~0xf, R8
+	ADDQ DI, R8          // Last
+	MOVQ DX, AX
+	ANDQ $0xf, AX        // Len8
+	JZ   aligned
+
+	// load the mask early
+	LEAQ    maskLen<>(SB), R9
+	MOVQ    $0x10, R10
+	SUBQ    AX, R10
+	VMOVDQU (R9)(R10*1), X8
+
+	// Don't read past the end of the page the last byte is on: if that's
+	// possible, load from Last rounded down to 16 and shuffle instead.
+	LEAQ -1(DI)(DX*1), R10
+	ORQ  $(MEOW_PAGESIZE-1), R10
+	SUBQ $16, R10        // LastOk
+	XORQ R11, R11        // Align
+	CMPQ R8, R10
+	JLS  lastok
+	MOVQ R8, R11
+	ANDQ $0xf, R11
+
+lastok:
+	LEAQ    shiftAdjust<>(SB), R9
+	VMOVDQU (R9)(R11*1), X10
+	NEGQ    R11
+	VMOVDQU (R8)(R11*1), X9
+	VPSHUFB X10, X9, X9
+
+	// and off the extra bytes
+	VPAND X8, X9, X9
+
+aligned:
+	// next, load the part that _is_ 16-byte aligned
+	TESTQ   $0x10, DX
+	JZ      injest
+	VMOVDQU X9, X11
+	VMOVDQU -0x10(R8), X9
+
+injest:
+	MEOW_INJEST
+	RET
+
+// hashCore is MeowHash(). It takes the seed in CX, the data in SI and the
+// length in DX, and leaves the hash in X8. It preserves CX and R12-R14, and
+// clobbers the other general purpose registers it uses and X0-X15.
+TEXT hashCore<>(SB), NOSPLIT, $0
+	MOVQ SI, DI          // SourceInit
+
+	LOAD_LANES(CX)
+
+	// hash all full 256-byte blocks
+	MOVQ DX, BX
+	SHRQ $8, BX
+	MEOW_BLOCKS
+
+	CALL residualCore<>(SB)
+	MEOW_LANES
+	MEOW_MIXDOWN(X0, X1, X2, X3, X4, X5, X6, X7)
+	MEOW_FOLD
+	RET
+
+// func hashAsm(seed *[SeedSize]byte, data []byte, out *[HashSize]byte)
+TEXT ·hashAsm(SB), NOSPLIT, $0-40
+	MOVQ seed+0(FP), CX
+	MOVQ data_base+8(FP), SI
+	MOVQ data_len+16(FP), DX
+	CALL hashCore<>(SB)
+
+	MOVQ    out+32(FP), AX
+	VMOVDQU X8, (AX)
+	RET
+
+// PAIR_LANE mixes full 32-byte lane n of both messages of a pair: the
+// message at SI with AX lanes into X0-X7, and the message at DI with BX
+// lanes into X8-X15. R8 is the larger of AX and BX.
+#define PAIR_LANE(n, off, a1, a2, a3, a4, a5, b1, b2, b3, b4, b5, skipa, skipb) \
+	CMPQ R8, $n; \
+	JB   pairmixdown; \
+	CMPQ AX, $n; \
+	JB   skipa; \
+	MEOW_MIX_AT(a1, a2, a3, a4, a5, off, SI); \
+skipa: \
+	CMPQ BX, $n; \
+	JB   skipb; \
+	MEOW_MIX_AT(b1, b2, b3, b4, b5, off, DI); \
+skipb:
+
+// func hashBatchAsm(seed *[SeedSize]byte, inputs [][]byte, out *[HashSize]byte)
+//
+// out must have room for len(inputs) hashes.
+//
+// Consecutive inputs shorter than BlockSize are hashed in pairs, with the
+// lanes of one message in X0-X7 and of the other in X8-X15. MeowHash() of a
+// short message is one long dependency chain of AES rounds, so interleaving
+// two independent chains keeps the AES unit busy. The residual injests of
+// both messages are built first and kept in the 256-byte frame.
+TEXT ·hashBatchAsm(SB), NOSPLIT, $256-40
+	MOVQ seed+0(FP), CX
+	MOVQ inputs_base+8(FP), R12
+	MOVQ inputs_len+16(FP), R13
+	MOVQ out+32(FP), R14
+	TESTQ R13, R13
+	JZ    done
+
+loop:
+	CMPQ R13, $2
+	JB   single
+	CMPQ 8(R12), $BlockSize
+	JAE  single
+	CMPQ 32(R12), $BlockSize
+	JAE  single
+
+	// residual and length injests of both messages
+	MOVQ    0(R12), DI
+	MOVQ    8(R12), DX
+	CALL    residualCore<>(SB)
+	VMOVDQU X8, 0x00(SP)
+	VMOVDQU X9, 0x10(SP)
+	VMOVDQU X10, 0x20(SP)
+	VMOVDQU X11, 0x30(SP)
+	VMOVDQU X12, 0x40(SP)
+	VMOVDQU X13, 0x50(SP)
+	VMOVDQU X14, 0x60(SP)
+	VMOVDQU X15, 0x70(SP)
+
+	MOVQ    24(R12), DI
+	MOVQ    32(R12), DX
+	CALL    residualCore<>(SB)
+	VMOVDQU X8, 0x80(SP)
+	VMOVDQU X9, 0x90(SP)
+	VMOVDQU X10, 0xa0(SP)
+	VMOVDQU X11, 0xb0(SP)
+	VMOVDQU X12, 0xc0(SP)
+	VMOVDQU X13, 0xd0(SP)
+	VMOVDQU X14, 0xe0(SP)
+	VMOVDQU X15, 0xf0(SP)
+
+	// seed both
+	LOAD_LANES(CX)
+	VMOVDQU X0, X8
+	VMOVDQU X1, X9
+	VMOVDQU X2, X10
+	VMOVDQU X3, X11
+	VMOVDQU X4, X12
+	VMOVDQU X5, X13
+	VMOVDQU X6, X14
+	VMOVDQU X7, X15
+
+	MEOW_MIX_REG(X0, X4, X6, X1, X2, 0x00(SP), 0x10(SP), 0x20(SP), 0x30(SP))
+	MEOW_MIX_REG(X8, X12, X14, X9, X10, 0x80(SP), 0x90(SP), 0xa0(SP), 0xb0(SP))
+	MEOW_MIX_REG(X1, X5, X7, X2, X3, 0x40(SP), 0x50(SP), 0x60(SP), 0x70(SP))
+	MEOW_MIX_REG(X9, X13, X15, X10, X11, 0xc0(SP), 0xd0(SP), 0xe0(SP), 0xf0(SP))
+
+	// full 32-byte lanes
+	MOVQ 0(R12), SI
+	MOVQ 8(R12), AX
+	SHRQ $5, AX
+	MOVQ 24(R12), DI
+	MOVQ 32(R12), BX
+	SHRQ $5, BX
+	MOVQ AX, R8
+	CMPQ BX, R8
+	CMOVQHI BX, R8
+
+	PAIR_LANE(1, 0x00, X2, X6, X0, X3, X4, X10, X14, X8, X11, X12, pair1a, pair1b)
+	PAIR_LANE(2, 0x20, X3, X7, X1, X4, X5, X11, X15, X9, X12, X13, pair2a, pair2b)
+	PAIR_LANE(3, 0x40, X4, X0, X2, X5, X6, X12, X8, X10, X13, X14, pair3a, pair3b)
+	PAIR_LANE(4, 0x60, X5, X1, X3, X6, X7, X13, X9, X11, X14, X15, pair4a, pair4b)
+	PAIR_LANE(5, 0x80, X6, X2, X4, X7, X0, X14, X10, X12, X15, X8, pair5a, pair5b)
+	PAIR_LANE(6, 0xa0, X7, X3, X5, X0, X1, X15, X11, X13, X8, X9, pair6a, pair6b)
+	PAIR_LANE(7, 0xc0, X0, X4, X6, X1, X2, X8, X12, X14, X9, X10, pair7a, pair7b)
+
+pairmixdown:
+	MEOW_SHUFFLE(X0, X1, X2, X4, X5, X6)
+	MEOW_SHUFFLE(X8, X9, X10, X12, X13, X14)
+	MEOW_SHUFFLE(X1, X2, X3, X5, X6, X7)
+	MEOW_SHUFFLE(X9, X10, X11, X13, X14, X15)
+	MEOW_SHUFFLE(X2, X3, X4, X6, X7, X0)
+	MEOW_SHUFFLE(X10, X11, X12, X14, X15, X8)
+	MEOW_SHUFFLE(X3, X4, X5, X7, X0, X1)
+	MEOW_SHUFFLE(X11, X12, X13, X15, X8, X9)
+	MEOW_SHUFFLE(X4, X5, X6, X0, X1, X2)
+	MEOW_SHUFFLE(X12, X13, X14, X8, X9, X10)
+	MEOW_SHUFFLE(X5, X6, X7, X1, X2, X3)
+	MEOW_SHUFFLE(X13, X14, X15, X9, X10, X11)
+	MEOW_SHUFFLE(X6, X7, X0, X2, X3, X4)
+	MEOW_SHUFFLE(X14, X15, X8, X10, X11, X12)
+	MEOW_SHUFFLE(X7, X0, X1, X3, X4, X5)
+	MEOW_SHUFFLE(X15, X8, X9, X11, X12, X13)
+	MEOW_SHUFFLE(X0, X1, X2, X4, X5, X6)
+	MEOW_SHUFFLE(X8, X9, X10, X12, X13, X14)
+	MEOW_SHUFFLE(X1, X2, X3, X5, X6, X7)
+	MEOW_SHUFFLE(X9, X10, X11, X13, X14, X15)
+	MEOW_SHUFFLE(X2, X3, X4, X6, X7, X0)
+	MEOW_SHUFFLE(X10, X11, X12, X14, X15, X8)
+	MEOW_SHUFFLE(X3, X4, X5, X7, X0, X1)
+	MEOW_SHUFFLE(X11, X12, X13, X15, X8, X9)
+
+	MEOW_FOLD_INPLACE(X0, X1, X2, X3, X4, X5, X6, X7)
+	MEOW_FOLD_INPLACE(X8, X9, X10, X11, X12, X13, X14, X15)
+	VMOVDQU X0, 0(R14)
+	VMOVDQU X8, HashSize(R14)
+
+	ADDQ $48, R12
+	ADDQ $(2*HashSize), R14
+	SUBQ $2, R13
+	JNZ  loop
+	RET
+
+single:
+	MOVQ 0(R12), SI      // inputs[i] base
+	MOVQ 8(R12), DX      // inputs[i] len
+	CALL hashCore<>(SB)
+	VMOVDQU X8, (R14)
+
+	ADDQ $24, R12
+	ADDQ $HashSize, R14
+	DECQ R13
+	JNZ  loop
+
+done:
+	RET
+
+// func absorbBlocksAsm(lanes *[SeedSize]byte, data []byte)
+TEXT ·absorbBlocksAsm(SB), NOSPLIT, $0-32
+	MOVQ lanes+0(FP), CX
+	MOVQ data_base+8(FP), SI
+	MOVQ data_len+16(FP), BX
+	SHRQ $8, BX
+
+	LOAD_LANES(CX)
+	MEOW_BLOCKS
+	STORE_LANES(CX)
+	RET
+
+// func finishAsm(lanes *[SeedSize]byte, total uint64, buf *[BlockSize]byte, nonce *[16]byte, out *[HashSize]byte)
+//
+// The post-mix lanes are stored back to lanes, like Store128 in MeowEnd().
+// If nonce isn't nil, it goes in the 128-bit nonce slot, X13.
+TEXT ·finishAsm(SB), NOSPLIT, $0-40
+	MOVQ lanes+0(FP), CX
+	MOVQ total+8(FP), DX
+	MOVQ buf+16(FP), SI
+
+	LOAD_LANES(CX)
+
+	// buf always has room for a 16 byte load at Last
+	MEOW_RESIDUAL_PADDED
+	MEOW_INJEST
+
+	MOVQ    nonce+24(FP), AX
+	TESTQ   AX, AX
+	JZ      lanes
+	VMOVDQU (AX), X13
+
+lanes:
+	MEOW_LANES
+	MEOW_MIXDOWN(X0, X1, X2, X3, X4, X5, X6, X7)
+	STORE_LANES(CX)
+	MEOW_FOLD
+
+	MOVQ    out+32(FP), AX
+	VMOVDQU X8, (AX)
+	RET
+
+// func hashPaddedAsm(seed *[SeedSize]byte, data []byte, out *[HashSize]byte)
+//
+// data must have room for a 16 byte load at len(data) rounded down to 16.
+TEXT ·hashPaddedAsm(SB), NOSPLIT, $0-40
+	MOVQ seed+0(FP), CX
+	MOVQ data_base+8(FP), SI
+	MOVQ data_len+16(FP), DX
+
+	LOAD_LANES(CX)
+
+	MOVQ DX, BX
+	SHRQ $8, BX
+	MEOW_BLOCKS
+
+	MEOW_RESIDUAL_PADDED
+	MEOW_INJEST
+	MEOW_LANES
+	MEOW_MIXDOWN(X0, X1, X2, X3, X4, X5, X6, X7)
+	MEOW_FOLD
+
+	MOVQ    out+32(FP), AX
+	VMOVDQU X8, (AX)
+	RET
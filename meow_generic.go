@@ -0,0 +1,310 @@
+package meow
+
+// This file is a portable Go port of MeowHash() and the streaming
+// construction from meow_hash_x64_aesni.h (see meow_amd64.s for the
+// upstream header and license). It is used when the assembly version
+// cannot be: on other architectures, with the purego build tag, or when the
+// CPU lacks the instructions it needs. The AES round is done in software,
+// so it is much slower than the AES-NI version, but it produces identical
+// hashes.
+
+import "encoding/binary"
+
+// u128 is the portable stand-in for an xmm register (meow_u128).
+// lo holds bytes 0-7 and hi holds bytes 8-15, both little endian.
+type u128 struct {
+	lo, hi uint64
+}
+
+// load reads 16 bytes from b (movdqu).
+func load(b []byte) u128 {
+	return u128{
+		lo: binary.LittleEndian.Uint64(b[0:8]),
+		hi: binary.LittleEndian.Uint64(b[8:16]),
+	}
+}
+
+// store writes x to the first 16 bytes of b (movdqu_mem).
+func store(b []byte, x u128) {
+	binary.LittleEndian.PutUint64(b[0:8], x.lo)
+	binary.LittleEndian.PutUint64(b[8:16], x.hi)
+}
+
+// paddq adds the two 64-bit halves of a and b.
+func paddq(a, b u128) u128 { return u128{a.lo + b.lo, a.hi + b.hi} }
+
+// pxor is a ^ b.
+func pxor(a, b u128) u128 { return u128{a.lo ^ b.lo, a.hi ^ b.hi} }
+
+// pand is a & b.
+func pand(a, b u128) u128 { return u128{a.lo & b.lo, a.hi & b.hi} }
+
+// palignr concatenates a (high) and b (low) and returns the 16 bytes
+// starting i bytes into b. Only the two shifts MeowHash uses are supported.
+func palignr(a, b u128, i uint) u128 {
+	switch i {
+	case 1:
+		return u128{b.lo>>8 | b.hi<<56, b.hi>>8 | a.lo<<56}
+	case 15:
+		return u128{a.lo<<8 | b.hi>>56, a.hi<<8 | a.lo>>56}
+	}
+	panic("meow: unsupported palignr shift")
+}
+
+// aesdec performs one round of AES decryption on state with round key
+// (the AESDEC instruction): InvShiftRows, InvSubBytes, InvMixColumns
+// and AddRoundKey.
+func aesdec(state, key u128) u128 {
+	s0 := uint32(state.lo)
+	s1 := uint32(state.lo >> 32)
+	s2 := uint32(state.hi)
+	s3 := uint32(state.hi >> 32)
+
+	t0 := td0[uint8(s0)] ^ td1[uint8(s3>>8)] ^ td2[uint8(s2>>16)] ^ td3[uint8(s1>>24)]
+	t1 := td0[uint8(s1)] ^ td1[uint8(s0>>8)] ^ td2[uint8(s3>>16)] ^ td3[uint8(s2>>24)]
+	t2 := td0[uint8(s2)] ^ td1[uint8(s1>>8)] ^ td2[uint8(s0>>16)] ^ td3[uint8(s3>>24)]
+	t3 := td0[uint8(s3)] ^ td1[uint8(s2>>8)] ^ td2[uint8(s1>>16)] ^ td3[uint8(s0>>24)]
+
+	return u128{
+		lo: (uint64(t0) | uint64(t1)<<32) ^ key.lo,
+		hi: (uint64(t2) | uint64(t3)<<32) ^ key.hi,
+	}
+}
+
+// lanes are the eight hash accumulation registers (xmm0-xmm7).
+type lanes [8]u128
+
+func (x *lanes) load(b *[SeedSize]byte) {
+	for i := range x {
+		x[i] = load(b[i*16:])
+	}
+}
+
+func (x *lanes) store(b *[SeedSize]byte) {
+	for i := range x {
+		store(b[i*16:], x[i])
+	}
+}
+
+// mixReg is MEOW_MIX_REG.
+func (x *lanes) mixReg(r1, r2, r3, r4, r5 int, i1, i2, i3, i4 u128) {
+	x[r1] = aesdec(x[r1], x[r2])
+	x[r3] = paddq(x[r3], i1)
+	x[r2] = pxor(x[r2], i2)
+	x[r2] = aesdec(x[r2], x[r4])
+	x[r5] = paddq(x[r5], i3)
+	x[r4] = pxor(x[r4], i4)
+}
+
+// mix is MEOW_MIX. It reads 32 bytes from p.
+func (x *lanes) mix(r1, r2, r3, r4, r5 int, p []byte) {
+	x.mixReg(r1, r2, r3, r4, r5, load(p[15:]), load(p[0:]), load(p[1:]), load(p[16:]))
+}
+
+// shuffle is MEOW_SHUFFLE.
+func (x *lanes) shuffle(r1, r2, r3, r4, r5, r6 int) {
+	x[r1] = aesdec(x[r1], x[r4])
+	x[r2] = paddq(x[r2], x[r5])
+	x[r4] = pxor(x[r4], x[r6])
+	x[r4] = aesdec(x[r4], x[r2])
+	x[r5] = paddq(x[r5], x[r6])
+	x[r2] = pxor(x[r2], x[r3])
+}
+
+// blocks hashes all full 256-byte blocks in p.
+func (x *lanes) blocks(p []byte) {
+	for ; len(p) >= BlockSize; p = p[BlockSize:] {
+		x.mix(0, 4, 6, 1, 2, p[0x00:])
+		x.mix(1, 5, 7, 2, 3, p[0x20:])
+		x.mix(2, 6, 0, 3, 4, p[0x40:])
+		x.mix(3, 7, 1, 4, 5, p[0x60:])
+		x.mix(4, 0, 2, 5, 6, p[0x80:])
+		x.mix(5, 1, 3, 6, 7, p[0xa0:])
+		x.mix(6, 2, 4, 7, 0, p[0xc0:])
+		x.mix(7, 3, 5, 0, 1, p[0xe0:])
+	}
+}
+
+// laneMixes are the register arguments of MEOW_MIX for each 32-byte lane
+// of the residual.
+var laneMixes = [7][5]int{
+	{2, 6, 0, 3, 4},
+	{3, 7, 1, 4, 5},
+	{4, 0, 2, 5, 6},
+	{5, 1, 3, 6, 7},
+	{6, 2, 4, 7, 0},
+	{7, 3, 5, 0, 1},
+	{0, 4, 6, 1, 2},
+}
+
+// end mixes in the residual and length, then mixes the eight lanes down
+// to one 128-bit hash. buf holds the last total%BlockSize bytes of input.
+// If nonce isn't nil, it goes in the 128-bit nonce slot, xmm13.
+func (x *lanes) end(total uint64, buf *[BlockSize]byte, nonce *[16]byte) u128 {
+	// load any less-than-32-byte residual
+	var xmm9, xmm11 u128
+	last := int(total & 0xf0)
+	if len8 := uint(total & 0xf); len8 != 0 {
+		xmm9 = load(buf[last:])
+		if len8 < 8 {
+			xmm9 = pand(xmm9, u128{1<<(8*len8) - 1, 0})
+		} else {
+			xmm9 = pand(xmm9, u128{^uint64(0), 1<<(8*(len8-8)) - 1})
+		}
+	}
+	if total&0x10 != 0 {
+		xmm11 = xmm9
+		xmm9 = load(buf[last-0x10:])
+	}
+
+	// construct the residual and length injests
+	xmm8 := palignr(xmm9, xmm11, 15)
+	xmm10 := palignr(xmm9, xmm11, 1)
+
+	// There is room for a 128-bit nonce (xmm13) and a 64-bit nonce (the
+	// high half of xmm15) here. Only HashNonce() fills in xmm13.
+	var xmm12, xmm13, xmm14 u128
+	if nonce != nil {
+		xmm13 = load(nonce[:])
+	}
+	xmm15 := u128{total, 0}
+	xmm12 = palignr(xmm12, xmm15, 15)
+	xmm14 = palignr(xmm14, xmm15, 1)
+
+	// always mix the less-than-32-byte residual, even if it was empty
+	x.mixReg(0, 4, 6, 1, 2, xmm8, xmm9, xmm10, xmm11)
+
+	// append the length
+	x.mixReg(1, 5, 7, 2, 3, xmm12, xmm13, xmm14, xmm15)
+
+	// hash all full 32-byte lanes
+	for i, r := range laneMixes[:(total>>5)&0x7] {
+		x.mix(r[0], r[1], r[2], r[3], r[4], buf[i*32:])
+	}
+
+	// mix the eight lanes down to one 128-bit hash
+	x.shuffle(0, 1, 2, 4, 5, 6)
+	x.shuffle(1, 2, 3, 5, 6, 7)
+	x.shuffle(2, 3, 4, 6, 7, 0)
+	x.shuffle(3, 4, 5, 7, 0, 1)
+	x.shuffle(4, 5, 6, 0, 1, 2)
+	x.shuffle(5, 6, 7, 1, 2, 3)
+	x.shuffle(6, 7, 0, 2, 3, 4)
+	x.shuffle(7, 0, 1, 3, 4, 5)
+	x.shuffle(0, 1, 2, 4, 5, 6)
+	x.shuffle(1, 2, 3, 5, 6, 7)
+	x.shuffle(2, 3, 4, 6, 7, 0)
+	x.shuffle(3, 4, 5, 7, 0, 1)
+
+	xmm0 := paddq(x[0], x[2])
+	xmm1 := paddq(x[1], x[3])
+	xmm4 := paddq(x[4], x[6])
+	xmm5 := paddq(x[5], x[7])
+	xmm0 = pxor(xmm0, xmm1)
+	xmm4 = pxor(xmm4, xmm5)
+	return paddq(xmm0, xmm4)
+}
+
+// sumGeneric hashes data using seed with the portable MeowHash.
+func sumGeneric(seed *[SeedSize]byte, data []byte) [HashSize]byte {
+	var x lanes
+	x.load(seed)
+	full := len(data) &^ (BlockSize - 1)
+	x.blocks(data[:full])
+
+	var buf [BlockSize]byte
+	copy(buf[:], data[full:])
+
+	var b [HashSize]byte
+	store(b[:], x.end(uint64(len(data)), &buf, nil))
+	return b
+}
+
+// sumBatchGeneric hashes each of inputs using seed into out, which must be
+// at least as long as inputs.
+func sumBatchGeneric(seed *[SeedSize]byte, inputs [][]byte, out [][HashSize]byte) {
+	out = out[:len(inputs)]
+	for i, data := range inputs {
+		out[i] = sumGeneric(seed, data)
+	}
+}
+
+// absorbBlocksGeneric mixes the full BlockSize blocks of data into lanes.
+// len(data) must be a multiple of BlockSize.
+func absorbBlocksGeneric(l *[SeedSize]byte, data []byte) {
+	if len(data) < BlockSize {
+		return
+	}
+	var x lanes
+	x.load(l)
+	x.blocks(data)
+	x.store(l)
+}
+
+// finishGeneric mixes the residual in buf and the total length into lanes
+// and folds them down to the final hash. buf holds the last
+// total%BlockSize bytes of input. The lanes are left in their post-mix
+// state, which is what MeowExpandSeed() uses as a seed. If nonce isn't
+// nil, it goes in the 128-bit nonce slot.
+func finishGeneric(l *[SeedSize]byte, total uint64, buf *[BlockSize]byte, nonce *[16]byte) [HashSize]byte {
+	var x lanes
+	x.load(l)
+	var b [HashSize]byte
+	store(b[:], x.end(total, buf, nonce))
+	x.store(l)
+	return b
+}
+
+// td0-td3 combine InvSubBytes and InvMixColumns for one byte of each row
+// of a column. td1-td3 are td0 rotated for rows 1-3.
+var td0, td1, td2, td3 [256]uint32
+
+func init() {
+	for i, s := range invSbox {
+		s9 := gmul(s, 0x09)
+		sb := gmul(s, 0x0b)
+		sd := gmul(s, 0x0d)
+		se := gmul(s, 0x0e)
+		w := uint32(se) | uint32(s9)<<8 | uint32(sd)<<16 | uint32(sb)<<24
+		td0[i] = w
+		td1[i] = w<<8 | w>>24
+		td2[i] = w<<16 | w>>16
+		td3[i] = w<<24 | w>>8
+	}
+}
+
+// gmul multiplies a and b in AES's GF(2^8).
+func gmul(a, b byte) byte {
+	var p byte
+	for ; b != 0; b >>= 1 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		hi := a & 0x80
+		a <<= 1
+		if hi != 0 {
+			a ^= 0x1b
+		}
+	}
+	return p
+}
+
+// invSbox is the AES inverse S-box.
+var invSbox = [256]byte{
+	0x52, 0x09, 0x6a, 0xd5, 0x30, 0x36, 0xa5, 0x38, 0xbf, 0x40, 0xa3, 0x9e, 0x81, 0xf3, 0xd7, 0xfb,
+	0x7c, 0xe3, 0x39, 0x82, 0x9b, 0x2f, 0xff, 0x87, 0x34, 0x8e, 0x43, 0x44, 0xc4, 0xde, 0xe9, 0xcb,
+	0x54, 0x7b, 0x94, 0x32, 0xa6, 0xc2, 0x23, 0x3d, 0xee, 0x4c, 0x95, 0x0b, 0x42, 0xfa, 0xc3, 0x4e,
+	0x08, 0x2e, 0xa1, 0x66, 0x28, 0xd9, 0x24, 0xb2, 0x76, 0x5b, 0xa2, 0x49, 0x6d, 0x8b, 0xd1, 0x25,
+	0x72, 0xf8, 0xf6, 0x64, 0x86, 0x68, 0x98, 0x16, 0xd4, 0xa4, 0x5c, 0xcc, 0x5d, 0x65, 0xb6, 0x92,
+	0x6c, 0x70, 0x48, 0x50, 0xfd, 0xed, 0xb9, 0xda, 0x5e, 0x15, 0x46, 0x57, 0xa7, 0x8d, 0x9d, 0x84,
+	0x90, 0xd8, 0xab, 0x00, 0x8c, 0xbc, 0xd3, 0x0a, 0xf7, 0xe4, 0x58, 0x05, 0xb8, 0xb3, 0x45, 0x06,
+	0xd0, 0x2c, 0x1e, 0x8f, 0xca, 0x3f, 0x0f, 0x02, 0xc1, 0xaf, 0xbd, 0x03, 0x01, 0x13, 0x8a, 0x6b,
+	0x3a, 0x91, 0x11, 0x41, 0x4f, 0x67, 0xdc, 0xea, 0x97, 0xf2, 0xcf, 0xce, 0xf0, 0xb4, 0xe6, 0x73,
+	0x96, 0xac, 0x74, 0x22, 0xe7, 0xad, 0x35, 0x85, 0xe2, 0xf9, 0x37, 0xe8, 0x1c, 0x75, 0xdf, 0x6e,
+	0x47, 0xf1, 0x1a, 0x71, 0x1d, 0x29, 0xc5, 0x89, 0x6f, 0xb7, 0x62, 0x0e, 0xaa, 0x18, 0xbe, 0x1b,
+	0xfc, 0x56, 0x3e, 0x4b, 0xc6, 0xd2, 0x79, 0x20, 0x9a, 0xdb, 0xc0, 0xfe, 0x78, 0xcd, 0x5a, 0xf4,
+	0x1f, 0xdd, 0xa8, 0x33, 0x88, 0x07, 0xc7, 0x31, 0xb1, 0x12, 0x10, 0x59, 0x27, 0x80, 0xec, 0x5f,
+	0x60, 0x51, 0x7f, 0xa9, 0x19, 0xb5, 0x4a, 0x0d, 0x2d, 0xe5, 0x7a, 0x9f, 0x93, 0xc9, 0x9c, 0xef,
+	0xa0, 0xe0, 0x3b, 0x4d, 0xae, 0x2a, 0xf5, 0xb0, 0xc8, 0xeb, 0xbb, 0x3c, 0x83, 0x53, 0x99, 0x61,
+	0x17, 0x2b, 0x04, 0x7e, 0xba, 0x77, 0xd6, 0x26, 0xe1, 0x69, 0x14, 0x63, 0x55, 0x21, 0x0c, 0x7d,
+}
@@ -0,0 +1,41 @@
+//go:build !amd64 || purego
+// +build !amd64 purego
+
+package meow
+
+// implementation is reported by Implementation().
+const implementation = "generic"
+
+// sum hashes data using seed.
+func sum(seed *[SeedSize]byte, data []byte) [HashSize]byte {
+	return sumGeneric(seed, data)
+}
+
+// sumPadded hashes data using seed. The portable version always copies the
+// residual, so it is the same as sum.
+func sumPadded(seed *[SeedSize]byte, data []byte) [HashSize]byte {
+	return sumGeneric(seed, data)
+}
+
+// sumBatch hashes each of inputs using seed into out, which must be at
+// least as long as inputs.
+func sumBatch(seed *[SeedSize]byte, inputs [][]byte, out [][HashSize]byte) {
+	sumBatchGeneric(seed, inputs, out)
+}
+
+// absorbBlocks mixes the full BlockSize blocks of data into lanes.
+// len(data) must be a multiple of BlockSize.
+func absorbBlocks(lanes *[SeedSize]byte, data []byte) {
+	absorbBlocksGeneric(lanes, data)
+}
+
+// finish mixes the residual in buf and the total length into lanes and
+// folds them down to the final hash. See finishGeneric.
+func finish(lanes *[SeedSize]byte, total uint64, buf *[BlockSize]byte) [HashSize]byte {
+	return finishGeneric(lanes, total, buf, nil)
+}
+
+// finishNonce is finish with nonce, if not nil, in the 128-bit nonce slot.
+func finishNonce(lanes *[SeedSize]byte, total uint64, buf *[BlockSize]byte, nonce *[16]byte) [HashSize]byte {
+	return finishGeneric(lanes, total, buf, nonce)
+}
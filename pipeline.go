@@ -0,0 +1,226 @@
+package meow
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// PipelineOptions configures HashFiles(). The zero value is valid.
+type PipelineOptions struct {
+	// Workers is the number of files hashed at once. Zero means
+	// runtime.GOMAXPROCS(0).
+	Workers int
+
+	// MemoryBudget bounds the bytes of read buffers held by the workers.
+	// Each worker gets an equal share, rounded down to a multiple of
+	// BlockSize and at most 64 KiB, and fewer workers are used if a share
+	// would be under 4 KiB. A budget under BlockSize still gets one worker
+	// with a BlockSize buffer. Zero means 64 KiB per worker.
+	MemoryBudget int64
+
+	// Ordered makes Results() deliver results in the order the paths were
+	// received, instead of as files are finished. At most 4*Workers files
+	// are in flight or waiting to be delivered, so one slow file stalls
+	// the pipeline rather than letting results pile up.
+	Ordered bool
+}
+
+// FileResult is the result of hashing one file in a Pipeline.
+type FileResult struct {
+	Path string
+	Hash Hash128 // zero if Err is not nil
+	Size int64   // bytes hashed
+	Err  error   // error opening or reading Path, or ctx.Err()
+}
+
+// PipelineStats are running totals for a Pipeline.
+type PipelineStats struct {
+	Files   int64         // files finished, including failed ones
+	Failed  int64         // files with a non-nil Err
+	Bytes   int64         // bytes hashed
+	Elapsed time.Duration // time since HashFiles(), or until Results() closed
+}
+
+// Pipeline hashes files in parallel. It is created by HashFiles().
+type Pipeline struct {
+	results chan FileResult
+
+	mu    sync.Mutex
+	stats PipelineStats
+	start time.Time
+	done  bool
+}
+
+// minPipelineBuffer is the smallest read buffer a Pipeline worker gets.
+const minPipelineBuffer = 4 << 10
+
+// HashFiles hashes the contents of each file named on paths, like
+// HashFile(), using several workers. Results are delivered on Results(),
+// which is closed once paths is closed and every file is done.
+//
+// A file that can't be hashed gives a FileResult with Err set, and the
+// pipeline carries on. Once ctx is done, files being hashed stop with
+// ctx.Err(), and no more paths are received; the sender on paths should
+// also watch ctx. The caller must read Results() until it is closed.
+func HashFiles(ctx context.Context, paths <-chan string, opts *PipelineOptions) *Pipeline {
+	if opts == nil {
+		opts = &PipelineOptions{}
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	size := int64(readBufferSize)
+	if opts.MemoryBudget > 0 {
+		if opts.MemoryBudget/int64(workers) < minPipelineBuffer {
+			workers = int(opts.MemoryBudget / minPipelineBuffer)
+			if workers < 1 {
+				workers = 1
+			}
+		}
+		size = opts.MemoryBudget / int64(workers) &^ (BlockSize - 1)
+		if size > readBufferSize {
+			size = readBufferSize
+		}
+		if size < BlockSize {
+			size = BlockSize
+		}
+	}
+
+	p := &Pipeline{
+		results: make(chan FileResult),
+		start:   time.Now(),
+	}
+	go p.run(ctx, paths, workers, int(size), opts.Ordered)
+	return p
+}
+
+// Results returns the channel results are delivered on.
+func (p *Pipeline) Results() <-chan FileResult { return p.results }
+
+// Stats returns the totals so far. Once Results() is closed they are final.
+func (p *Pipeline) Stats() PipelineStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := p.stats
+	if !p.done {
+		s.Elapsed = time.Since(p.start)
+	}
+	return s
+}
+
+// pipelineJob is a path and its position in the input.
+type pipelineJob struct {
+	seq  int64
+	path string
+}
+
+// pipelineResult is a FileResult and the position of its path.
+type pipelineResult struct {
+	seq int64
+	FileResult
+}
+
+// run dispatches paths to workers and delivers their results.
+func (p *Pipeline) run(ctx context.Context, paths <-chan string, workers, size int, ordered bool) {
+	jobs := make(chan pipelineJob)
+	finished := make(chan pipelineResult, workers)
+	// a token is taken per file in flight or waiting to be delivered
+	window := make(chan struct{}, 4*workers)
+
+	go func() {
+		defer close(jobs)
+		var seq int64
+		for {
+			var path string
+			var ok bool
+			select {
+			case path, ok = <-paths:
+			case <-ctx.Done():
+			}
+			if !ok {
+				return
+			}
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			jobs <- pipelineJob{seq, path}
+			seq++
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			buf := make([]byte, size)
+			for j := range jobs {
+				r := pipelineResult{seq: j.seq}
+				r.Path = j.path
+				r.Hash, r.Size, r.Err = hashFileBuffer(ctx, j.path, buf)
+				finished <- r
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+
+	// pending holds results that finished ahead of their turn
+	pending := make(map[int64]FileResult)
+	var next int64
+	for r := range finished {
+		if !ordered {
+			p.deliver(r.FileResult)
+			<-window
+			continue
+		}
+		pending[r.seq] = r.FileResult
+		for {
+			x, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			p.deliver(x)
+			<-window
+		}
+	}
+
+	p.mu.Lock()
+	p.stats.Elapsed = time.Since(p.start)
+	p.done = true
+	p.mu.Unlock()
+	close(p.results)
+}
+
+// deliver adds r to the totals and sends it on Results().
+func (p *Pipeline) deliver(r FileResult) {
+	p.mu.Lock()
+	p.stats.Files++
+	p.stats.Bytes += r.Size
+	if r.Err != nil {
+		p.stats.Failed++
+	}
+	p.mu.Unlock()
+	p.results <- r
+}
+
+// hashFileBuffer hashes the named file like HashFileContext(), reading
+// into buf.
+func hashFileBuffer(ctx context.Context, name string, buf []byte) (Hash128, int64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Hash128{}, 0, err
+	}
+	defer f.Close()
+	return hashReaderBuffer(ctx, f, buf, &HashOptions{})
+}
@@ -0,0 +1,150 @@
+package meow
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// pipelineFiles writes n files of decreasing size, so later files tend to
+// finish first, and returns their names and contents.
+func pipelineFiles(t *testing.T, n int) ([]string, map[string][]byte) {
+	data := testData(n * 3000)
+	names := make([]string, n)
+	contents := make(map[string][]byte)
+	for i := range names {
+		b := data[i : i+(n-i)*3000]
+		names[i] = tempFile(t, b)
+		contents[names[i]] = b
+	}
+	return names, contents
+}
+
+// sendPaths sends names on a new channel and closes it.
+func sendPaths(names []string) <-chan string {
+	paths := make(chan string)
+	go func() {
+		defer close(paths)
+		for _, name := range names {
+			paths <- name
+		}
+	}()
+	return paths
+}
+
+// collect reads p.Results() until it is closed, or fails after a while.
+func collect(t *testing.T, p *Pipeline) []FileResult {
+	var results []FileResult
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case r, ok := <-p.Results():
+			if !ok {
+				return results
+			}
+			results = append(results, r)
+		case <-timeout:
+			t.Fatal("Results() not closed")
+		}
+	}
+}
+
+func TestHashFilesOrdered(t *testing.T) {
+	names, contents := pipelineFiles(t, 40)
+	for _, name := range names {
+		defer os.Remove(name)
+	}
+	p := HashFiles(context.Background(), sendPaths(names), &PipelineOptions{Workers: 4, Ordered: true})
+	results := collect(t, p)
+	if len(results) != len(names) {
+		t.Fatalf("got %d results, want %d", len(results), len(names))
+	}
+	for i, r := range results {
+		want := contents[names[i]]
+		if r.Path != names[i] || r.Err != nil || r.Size != int64(len(want)) || !bytes.Equal(r.Hash[:], Hash(want)) {
+			t.Errorf("result %d = %+v, want %s with %d bytes", i, r, names[i], len(want))
+		}
+	}
+}
+
+func TestHashFilesUnordered(t *testing.T) {
+	names, contents := pipelineFiles(t, 40)
+	for _, name := range names {
+		defer os.Remove(name)
+	}
+	// a small budget also exercises fewer workers with small buffers
+	for _, opts := range []*PipelineOptions{nil, {Workers: 8, MemoryBudget: 10000}, {MemoryBudget: 1}} {
+		seen := make(map[string]int)
+		for _, r := range collect(t, HashFiles(context.Background(), sendPaths(names), opts)) {
+			seen[r.Path]++
+			want := contents[r.Path]
+			if r.Err != nil || !bytes.Equal(r.Hash[:], Hash(want)) {
+				t.Errorf("%+v: result %+v, want %d bytes hashed", opts, r, len(want))
+			}
+		}
+		for _, name := range names {
+			if seen[name] != 1 {
+				t.Errorf("%+v: %s delivered %d times, want once", opts, name, seen[name])
+			}
+		}
+		if len(seen) != len(names) {
+			t.Errorf("%+v: delivered %d paths, want %d", opts, len(seen), len(names))
+		}
+	}
+}
+
+func TestHashFilesErrors(t *testing.T) {
+	names, contents := pipelineFiles(t, 3)
+	for _, name := range names {
+		defer os.Remove(name)
+	}
+	missing := filepath.Join(os.TempDir(), "meow-missing-file")
+	paths := []string{names[0], missing, names[1], names[2]}
+	p := HashFiles(context.Background(), sendPaths(paths), &PipelineOptions{Workers: 2, Ordered: true})
+	results := collect(t, p)
+	if len(results) != len(paths) {
+		t.Fatalf("got %d results, want %d", len(results), len(paths))
+	}
+	if r := results[1]; r.Path != missing || !os.IsNotExist(r.Err) || r.Hash != (Hash128{}) {
+		t.Errorf("missing file result = %+v, want a not exist error", r)
+	}
+
+	var total int64
+	for _, name := range names {
+		total += int64(len(contents[name]))
+	}
+	s := p.Stats()
+	if s.Files != 4 || s.Failed != 1 || s.Bytes != total || s.Elapsed <= 0 {
+		t.Errorf("Stats() = %+v, want 4 files, 1 failed, %d bytes", s, total)
+	}
+	if s2 := p.Stats(); s2 != s {
+		t.Errorf("Stats() changed after Results() closed: %+v, then %+v", s, s2)
+	}
+}
+
+func TestHashFilesCancel(t *testing.T) {
+	names, _ := pipelineFiles(t, 4)
+	for _, name := range names {
+		defer os.Remove(name)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	// paths is never closed, so only ctx can end the pipeline
+	paths := make(chan string)
+	p := HashFiles(ctx, paths, &PipelineOptions{Workers: 2})
+	for _, name := range names {
+		paths <- name
+	}
+	cancel()
+	results := collect(t, p)
+	if len(results) > len(names) {
+		t.Errorf("got %d results for %d paths", len(results), len(names))
+	}
+	for _, r := range results {
+		if r.Err != nil && r.Err != context.Canceled {
+			t.Errorf("result %+v, want no error or %v", r, context.Canceled)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package meow
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// readBufferSize is the size of the buffer used to stream input from an
+// io.Reader. It is a multiple of BlockSize, so full reads are absorbed
+// straight from the buffer.
+const readBufferSize = 64 << 10
+
+// HashReader hashes everything read from r until io.EOF using
+// MeowDefaultSeed, through a fixed size buffer. It returns the hash and the
+// number of bytes read, which give the same hash with Hash(). On a read
+// error it returns the bytes read so far and the error, with a zero hash.
+func HashReader(r io.Reader) (Hash128, int64, error) {
+	return hashReaderBuffer(context.Background(), r, make([]byte, readBufferSize), &HashOptions{})
+}
+
+// HashFile hashes the contents of the named file like HashReader().
+func HashFile(name string) (Hash128, int64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Hash128{}, 0, err
+	}
+	defer f.Close()
+	return HashReader(f)
+}
+
+// HashReaderAt hashes the n bytes of r starting at offset off, such as a
+// byte range of a file, like HashReader(). If r ends before off+n it
+// returns the bytes read and io.ErrUnexpectedEOF.
+func HashReaderAt(r io.ReaderAt, off, n int64) (Hash128, int64, error) {
+	h, read, err := HashReader(io.NewSectionReader(r, off, n))
+	if err == nil && read < n {
+		return Hash128{}, read, io.ErrUnexpectedEOF
+	}
+	return h, read, err
+}
+
+// HashOptions configures HashReaderContext(). The zero value is valid.
+type HashOptions struct {
+	// BufferSize is the size of the read buffer, rounded up to a multiple
+	// of BlockSize. Zero means 64 KiB.
+	BufferSize int
+
+	// Progress, if not nil, is called from the hashing goroutine after
+	// reads, at most once per ProgressInterval, and once more when the
+	// input is finished.
+	Progress func(Progress)
+
+	// ProgressInterval is the least time between calls to Progress. Zero
+	// means every read.
+	ProgressInterval time.Duration
+}
+
+// Progress reports how far a hash has got.
+type Progress struct {
+	Bytes          int64         // bytes hashed so far
+	Elapsed        time.Duration // time since hashing started
+	BytesPerSecond float64       // average throughput so far
+}
+
+// HashReaderContext hashes r like HashReader(), but stops with ctx.Err()
+// once ctx is done, and reports progress as configured by opts, which may
+// be nil. ctx is checked between reads, so a single Read() that blocks is
+// not interrupted; close r to unblock it.
+func HashReaderContext(ctx context.Context, r io.Reader, opts *HashOptions) (Hash128, int64, error) {
+	if opts == nil {
+		opts = &HashOptions{}
+	}
+	size := readBufferSize
+	if opts.BufferSize > 0 {
+		size = (opts.BufferSize + BlockSize - 1) &^ (BlockSize - 1)
+	}
+	return hashReaderBuffer(ctx, r, make([]byte, size), opts)
+}
+
+// hashReaderBuffer does HashReaderContext(), reading into buf.
+func hashReaderBuffer(ctx context.Context, r io.Reader, buf []byte, opts *HashOptions) (Hash128, int64, error) {
+	h := meowHash{seed: MeowDefaultSeed}
+	h.Reset()
+	var n int64
+	start := time.Now()
+	var last time.Time
+	report := func(now time.Time) {
+		p := Progress{Bytes: n, Elapsed: now.Sub(start)}
+		if p.Elapsed > 0 {
+			p.BytesPerSecond = float64(n) / p.Elapsed.Seconds()
+		}
+		opts.Progress(p)
+		last = now
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return Hash128{}, n, err
+		}
+		m, err := r.Read(buf)
+		h.Write(buf[:m])
+		n += int64(m)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Hash128{}, n, err
+		}
+		if opts.Progress != nil {
+			if now := time.Now(); now.Sub(last) >= opts.ProgressInterval {
+				report(now)
+			}
+		}
+	}
+	if opts.Progress != nil {
+		report(time.Now())
+	}
+	return h.sum128(), n, nil
+}
+
+// HashFileContext hashes the contents of the named file like
+// HashReaderContext().
+func HashFileContext(ctx context.Context, name string, opts *HashOptions) (Hash128, int64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Hash128{}, 0, err
+	}
+	defer f.Close()
+	return HashReaderContext(ctx, f, opts)
+}
@@ -0,0 +1,182 @@
+package meow
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+// readSizes records the size of the buffers passed to Read.
+type readSizes struct {
+	r     io.Reader
+	sizes map[int]bool
+}
+
+func (r *readSizes) Read(p []byte) (int, error) {
+	r.sizes[len(p)] = true
+	return r.r.Read(p)
+}
+
+func TestHashReader(t *testing.T) {
+	data := testData(200000)
+	readers := map[string]func([]byte) io.Reader{
+		"bytes.Reader": func(b []byte) io.Reader { return bytes.NewReader(b) },
+		"OneByteReader": func(b []byte) io.Reader {
+			return iotest.OneByteReader(bytes.NewReader(b))
+		},
+		"HalfReader": func(b []byte) io.Reader {
+			return iotest.HalfReader(bytes.NewReader(b))
+		},
+	}
+	for _, n := range []int{1, 255, 256, 257, readBufferSize, readBufferSize + 1, len(data)} {
+		want := Hash(data[:n])
+		for name, newReader := range readers {
+			h, size, err := HashReader(newReader(data[:n]))
+			if err != nil || size != int64(n) || !bytes.Equal(h[:], want) {
+				t.Errorf("HashReader(%s of %d bytes) = %x, %d, %v, want %x, %d", name, n, h, size, err, want, n)
+			}
+		}
+
+		name := tempFile(t, data[:n])
+		h, size, err := HashFile(name)
+		os.Remove(name)
+		if err != nil || size != int64(n) || !bytes.Equal(h[:], want) {
+			t.Errorf("HashFile(%d bytes) = %x, %d, %v, want %x, %d", n, h, size, err, want, n)
+		}
+	}
+
+	if _, _, err := HashFile("does/not/exist"); !os.IsNotExist(err) {
+		t.Errorf("HashFile of a missing file = %v, want not exist", err)
+	}
+}
+
+func TestHashReaderBuffer(t *testing.T) {
+	r := &readSizes{r: bytes.NewReader(testData(300000)), sizes: map[int]bool{}}
+	HashReader(r)
+	if len(r.sizes) != 1 || !r.sizes[readBufferSize] {
+		t.Errorf("HashReader read with buffers of %v bytes, want only %d", r.sizes, readBufferSize)
+	}
+}
+
+func TestHashReaderError(t *testing.T) {
+	data := testData(1000)
+	errRead := errors.New("read failed")
+	r := io.MultiReader(bytes.NewReader(data[:300]), &errReader{errRead})
+	h, n, err := HashReader(r)
+	if err != errRead || n != 300 || h != (Hash128{}) {
+		t.Errorf("HashReader() = %x, %d, %v, want zero hash, 300, %v", h, n, err, errRead)
+	}
+}
+
+// errReader fails every read with err.
+type errReader struct{ err error }
+
+func (r *errReader) Read(p []byte) (int, error) { return 0, r.err }
+
+func TestHashReaderAt(t *testing.T) {
+	data := testData(10000)
+	r := bytes.NewReader(data)
+
+	h, n, err := HashReaderAt(r, 1000, 5000)
+	if want := Hash(data[1000:6000]); err != nil || n != 5000 || !bytes.Equal(h[:], want) {
+		t.Errorf("HashReaderAt(1000, 5000) = %x, %d, %v, want %x, 5000", h, n, err, want)
+	}
+	h, n, err = HashReaderAt(r, 0, 0)
+	if want := Hash(nil); err != nil || n != 0 || !bytes.Equal(h[:], want) {
+		t.Errorf("HashReaderAt(0, 0) = %x, %d, %v, want %x, 0", h, n, err, want)
+	}
+	h, n, err = HashReaderAt(r, 8000, 5000)
+	if err != io.ErrUnexpectedEOF || n != 2000 || h != (Hash128{}) {
+		t.Errorf("HashReaderAt(8000, 5000) = %x, %d, %v, want zero hash, 2000, %v", h, n, err, io.ErrUnexpectedEOF)
+	}
+}
+
+// cancelAfter cancels a context once n bytes have been read.
+type cancelAfter struct {
+	r      io.Reader
+	n      int64
+	cancel func()
+}
+
+func (r *cancelAfter) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if r.n -= int64(n); r.n <= 0 {
+		r.cancel()
+	}
+	return n, err
+}
+
+func TestHashReaderContextCancel(t *testing.T) {
+	data := testData(100000)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &cancelAfter{r: bytes.NewReader(data), n: 3000, cancel: cancel}
+	h, n, err := HashReaderContext(ctx, r, &HashOptions{BufferSize: 1024})
+	if err != context.Canceled || n != 3072 || h != (Hash128{}) {
+		t.Errorf("HashReaderContext() = %x, %d, %v, want zero hash, 3072, %v", h, n, err, context.Canceled)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if _, n, err := HashReaderContext(ctx, bytes.NewReader(data), nil); err != context.Canceled || n != 0 {
+		t.Errorf("HashReaderContext(cancelled) = %d, %v, want 0, %v", n, err, context.Canceled)
+	}
+}
+
+func TestHashReaderContextProgress(t *testing.T) {
+	data := testData(100000)
+	var calls []Progress
+	opts := &HashOptions{
+		BufferSize: 4096,
+		Progress:   func(p Progress) { calls = append(calls, p) },
+	}
+	h, n, err := HashReaderContext(context.Background(), bytes.NewReader(data), opts)
+	if want := Hash(data); err != nil || n != int64(len(data)) || !bytes.Equal(h[:], want) {
+		t.Fatalf("HashReaderContext() = %x, %d, %v, want %x, %d", h, n, err, want, len(data))
+	}
+	if len(calls) < 2 {
+		t.Fatalf("Progress called %d times, want one per read and a final call", len(calls))
+	}
+	for i := 1; i < len(calls); i++ {
+		if calls[i].Bytes < calls[i-1].Bytes || calls[i].Elapsed < calls[i-1].Elapsed {
+			t.Errorf("Progress call %d = %+v went backwards from %+v", i, calls[i], calls[i-1])
+		}
+	}
+	if last := calls[len(calls)-1]; last.Bytes != int64(len(data)) || last.BytesPerSecond <= 0 {
+		t.Errorf("final Progress = %+v, want %d bytes and a throughput", last, len(data))
+	}
+
+	// with an interval longer than the hash, only the final call remains
+	calls = nil
+	opts.ProgressInterval = time.Hour
+	HashReaderContext(context.Background(), bytes.NewReader(data), opts)
+	if len(calls) > 2 || calls[len(calls)-1].Bytes != int64(len(data)) {
+		t.Errorf("Progress with a long interval = %+v, want the first read and the final call", calls)
+	}
+}
+
+func TestHashReaderContextBufferSize(t *testing.T) {
+	data := testData(10000)
+	for size, want := range map[int]int{0: readBufferSize, 1: BlockSize, 256: 256, 257: 512, 1000: 1024} {
+		r := &readSizes{r: bytes.NewReader(data), sizes: map[int]bool{}}
+		HashReaderContext(context.Background(), r, &HashOptions{BufferSize: size})
+		if len(r.sizes) != 1 || !r.sizes[want] {
+			t.Errorf("BufferSize %d read with buffers of %v bytes, want %d", size, r.sizes, want)
+		}
+	}
+}
+
+func TestHashFileContext(t *testing.T) {
+	data := testData(5000)
+	name := tempFile(t, data)
+	defer os.Remove(name)
+	h, n, err := HashFileContext(context.Background(), name, nil)
+	if want := Hash(data); err != nil || n != int64(len(data)) || !bytes.Equal(h[:], want) {
+		t.Errorf("HashFileContext() = %x, %d, %v, want %x, %d", h, n, err, want, len(data))
+	}
+}
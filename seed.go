@@ -0,0 +1,265 @@
+package meow
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// Seed is a MeowHash seed. A Seed can be passed anywhere a [SeedSize]byte
+// is expected, such as HashSeed().
+//
+// MeowDefaultSeed is public, so anyone can craft inputs that collide under
+// it. When hashing untrusted input for maps or caches, use a random seed
+// from MakeSeed() instead. Keep MeowDefaultSeed for persistent digests
+// that have to be reproducible.
+//
+// Seeds are secrets, so Seed has no String() method and errors never
+// include seed bytes. Use Fingerprint() to identify a seed in logs.
+// Seed implements encoding.TextMarshaler and encoding.TextUnmarshaler
+// using Hex(), and encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler using the raw SeedSize bytes.
+type Seed [SeedSize]byte
+
+var (
+	errSeedLen  = errors.New("meow: seed must be 128 bytes")
+	errSeedText = errors.New("meow: seed is not valid hex or base64")
+	errSeedZero = errors.New("meow: seed is all zero")
+	errSeedWeak = errors.New("meow: seed is a short repeated pattern")
+)
+
+// maxSeedText is the most text ReadSeed() reads: a hex seed and some
+// whitespace.
+const maxSeedText = 4 * SeedSize
+
+// MakeSeed returns a new random seed from crypto/rand.
+// It panics if crypto/rand fails.
+func MakeSeed() Seed {
+	var s Seed
+	if _, err := rand.Read(s[:]); err != nil {
+		panic("meow: cannot read random seed: " + err.Error())
+	}
+	return s
+}
+
+// Bytes returns the 64-bit hash of b using seed, like Sum64().
+// It does not allocate.
+func Bytes(seed Seed, b []byte) uint64 {
+	x := sum((*[SeedSize]byte)(&seed), b)
+	return binary.LittleEndian.Uint64(x[0:8])
+}
+
+// StringSum returns the 64-bit hash of s using seed, like Bytes(). It is
+// not named String, which formats hashes. It does not copy s.
+func StringSum(seed Seed, s string) uint64 {
+	return Bytes(seed, stringBytes(s))
+}
+
+// Hasher hashes a sequence of bytes with a Seed, in the style of
+// hash/maphash. Hasher implements hash.Hash64, io.StringWriter and
+// io.ByteWriter.
+//
+// The zero Hasher is ready to use: it picks a random seed with MakeSeed()
+// the first time it is used. A Hasher must not be copied after first use.
+type Hasher struct {
+	h      meowHash
+	seeded bool
+}
+
+// initSeed picks a random seed if h doesn't have one yet.
+func (h *Hasher) initSeed() {
+	if !h.seeded {
+		h.SetSeed(MakeSeed())
+	}
+}
+
+// Seed returns h's seed, picking a random one first if needed.
+func (h *Hasher) Seed() Seed {
+	h.initSeed()
+	return h.h.seed
+}
+
+// SetSeed sets h to use seed and resets it.
+func (h *Hasher) SetSeed(seed Seed) {
+	h.h.seed = seed
+	h.h.Reset()
+	h.seeded = true
+}
+
+// Write adds b to the sequence of bytes hashed by h. It always writes all
+// of b and never fails.
+func (h *Hasher) Write(b []byte) (int, error) {
+	h.initSeed()
+	return h.h.Write(b)
+}
+
+// WriteString adds the bytes of s to the sequence of bytes hashed by h.
+// It always writes all of s and never fails.
+func (h *Hasher) WriteString(s string) (int, error) {
+	h.initSeed()
+	return h.h.WriteString(s)
+}
+
+// WriteByte adds c to the sequence of bytes hashed by h. It never fails.
+func (h *Hasher) WriteByte(c byte) error {
+	h.initSeed()
+	return h.h.WriteByte(c)
+}
+
+// Reset discards all bytes added to h. The seed is kept.
+func (h *Hasher) Reset() {
+	h.initSeed()
+	h.h.Reset()
+}
+
+// Sum64 returns h's current 64-bit value: the first 8 bytes of the 128-bit
+// hash as a little endian uint64, like Bytes().
+func (h *Hasher) Sum64() uint64 {
+	h.initSeed()
+	x := h.h.sum128()
+	return binary.LittleEndian.Uint64(x[0:8])
+}
+
+// Sum128 returns h's current 128-bit hash.
+func (h *Hasher) Sum128() Hash128 {
+	h.initSeed()
+	return h.h.sum128()
+}
+
+// Sum appends Sum64() to b in big endian order, like maphash.Hash.
+func (h *Hasher) Sum(b []byte) []byte {
+	var x [8]byte
+	binary.BigEndian.PutUint64(x[:], h.Sum64())
+	return append(b, x[:]...)
+}
+
+// Size returns h's hash value size, 8 bytes.
+func (h *Hasher) Size() int { return 8 }
+
+// BlockSize returns h's block size.
+func (h *Hasher) BlockSize() int { return BlockSize }
+
+// Hash returns the hash of data using s. Unlike HashSeed(), s is not
+// copied on every call.
+func (s *Seed) Hash(data []byte) Hash128 {
+	return sum((*[SeedSize]byte)(s), data)
+}
+
+// Fingerprint identifies s without revealing it, as 16 lowercase hex
+// digits. It is the first 8 bytes of the hash of s using MeowDefaultSeed,
+// as little endian, and matches the fingerprint stored by MarshalBinary()
+// on the streaming hasher.
+func (s *Seed) Fingerprint() string {
+	return fmt.Sprintf("%016x", seedFingerprint((*[SeedSize]byte)(s)))
+}
+
+// Validate rejects degenerate seeds: all zero bytes, or a pattern of 64
+// bytes or fewer repeated over the whole seed (such as one repeated byte).
+// MakeSeed(), MeowDefaultSeed and ExpandSeed() results pass.
+func (s *Seed) Validate() error {
+	if *s == (Seed{}) {
+		return errSeedZero
+	}
+	for p := 1; p <= SeedSize/2; p++ {
+		if bytes.Equal(s[:SeedSize-p], s[p:]) {
+			return errSeedWeak
+		}
+	}
+	return nil
+}
+
+// Hex encodes s as 256 lowercase hex digits. ParseSeed() reverses it.
+func (s *Seed) Hex() string { return hex.EncodeToString(s[:]) }
+
+// Base64 encodes s with the URL safe base64 alphabet and no padding, like
+// Hash128.Base64(). ParseSeed() reverses it.
+func (s *Seed) Base64() string { return base64.RawURLEncoding.EncodeToString(s[:]) }
+
+// ParseSeed parses the Hex() or Base64() format of a seed, ignoring leading
+// and trailing whitespace. The seed must
+// pass Validate().
+func ParseSeed(text string) (Seed, error) {
+	var s Seed
+	text = strings.TrimSpace(text)
+	var b []byte
+	var err error
+	if len(text) == 2*SeedSize {
+		b, err = hex.DecodeString(text)
+	} else {
+		b, err = base64.RawURLEncoding.DecodeString(text)
+	}
+	if err != nil {
+		return s, errSeedText
+	}
+	if len(b) != SeedSize {
+		return s, errSeedLen
+	}
+	copy(s[:], b)
+	if err := s.Validate(); err != nil {
+		return Seed{}, err
+	}
+	return s, nil
+}
+
+// ReadSeed reads a seed in a format accepted by ParseSeed() from r, such as
+// a seed file written with Hex().
+func ReadSeed(r io.Reader) (Seed, error) {
+	text, err := ioutil.ReadAll(io.LimitReader(r, maxSeedText+1))
+	if err != nil {
+		return Seed{}, err
+	}
+	if len(text) > maxSeedText {
+		return Seed{}, errSeedText
+	}
+	return ParseSeed(string(text))
+}
+
+// LoadSeed reads a seed from the named file with ReadSeed().
+func LoadSeed(name string) (Seed, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return Seed{}, err
+	}
+	defer f.Close()
+	return ReadSeed(f)
+}
+
+// MarshalText implements encoding.TextMarshaler using Hex().
+func (s Seed) MarshalText() ([]byte, error) { return []byte(s.Hex()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseSeed().
+func (s *Seed) UnmarshalText(text []byte) error {
+	x, err := ParseSeed(string(text))
+	if err != nil {
+		return err
+	}
+	*s = x
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. It returns the
+// SeedSize bytes of s.
+func (s Seed) MarshalBinary() ([]byte, error) { return s[:], nil }
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. data must be
+// SeedSize bytes long and pass Validate().
+func (s *Seed) UnmarshalBinary(data []byte) error {
+	if len(data) != SeedSize {
+		return errSeedLen
+	}
+	var x Seed
+	copy(x[:], data)
+	if err := x.Validate(); err != nil {
+		return err
+	}
+	*s = x
+	return nil
+}
@@ -0,0 +1,173 @@
+package meow
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"testing"
+)
+
+// repeatedSeed returns a seed made of the bytes 1, 2, ... period repeated.
+func repeatedSeed(period int) Seed {
+	var s Seed
+	for i := range s {
+		s[i] = byte(i%period) + 1
+	}
+	return s
+}
+
+func TestSeedValidate(t *testing.T) {
+	good := map[string]Seed{
+		"MeowDefaultSeed":  MeowDefaultSeed,
+		"testSeed":         testSeed(),
+		"ExpandSeed":       ExpandSeed([]byte("meow")),
+		"MakeSeed":         MakeSeed(),
+		"period 65":        repeatedSeed(65),
+		"period 127":       repeatedSeed(127),
+		"one byte changed": func() Seed { s := repeatedSeed(1); s[100]++; return s }(),
+	}
+	for name, s := range good {
+		if err := s.Validate(); err != nil {
+			t.Errorf("%s: Validate() = %v, want nil", name, err)
+		}
+	}
+
+	bad := map[string]struct {
+		s   Seed
+		err error
+	}{
+		"zero":      {Seed{}, errSeedZero},
+		"period 1":  {repeatedSeed(1), errSeedWeak},
+		"period 2":  {repeatedSeed(2), errSeedWeak},
+		"period 3":  {repeatedSeed(3), errSeedWeak},
+		"period 16": {repeatedSeed(16), errSeedWeak},
+		"period 37": {repeatedSeed(37), errSeedWeak},
+		"period 64": {repeatedSeed(64), errSeedWeak},
+	}
+	for name, tt := range bad {
+		if err := tt.s.Validate(); err != tt.err {
+			t.Errorf("%s: Validate() = %v, want %v", name, err, tt.err)
+		}
+		if _, err := ParseSeed(tt.s.Hex()); err != tt.err {
+			t.Errorf("%s: ParseSeed() = %v, want %v", name, err, tt.err)
+		}
+	}
+}
+
+func TestParseSeed(t *testing.T) {
+	s := Seed(testSeed())
+	texts := []string{
+		s.Hex(),
+		strings.ToUpper(s.Hex()),
+		" " + s.Hex() + "\n",
+		s.Base64(),
+		"\t" + s.Base64() + "\n",
+	}
+	for _, text := range texts {
+		got, err := ParseSeed(text)
+		if err != nil || got != s {
+			t.Errorf("ParseSeed(%q) = %x, %v, want %x", text, got, err, s)
+		}
+		got, err = ReadSeed(strings.NewReader(text))
+		if err != nil || got != s {
+			t.Errorf("ReadSeed(%q) = %x, %v, want %x", text, got, err, s)
+		}
+	}
+	for _, text := range []string{"", "meow", s.Hex()[2:], s.Hex() + "00", strings.Repeat("0", 1000), s.Base64() + "=",
+		base64.StdEncoding.EncodeToString(s[:])} {
+		if _, err := ParseSeed(text); err == nil {
+			t.Errorf("ParseSeed(%q) succeeded", text)
+		}
+	}
+}
+
+func TestMakeSeed(t *testing.T) {
+	a, b := MakeSeed(), MakeSeed()
+	if a == b {
+		t.Errorf("MakeSeed() returned the same seed twice")
+	}
+	if err := a.Validate(); err != nil {
+		t.Errorf("MakeSeed().Validate() = %v", err)
+	}
+}
+
+func TestHasherZero(t *testing.T) {
+	data := testData(1000)
+	var h Hasher
+	h.Write(data)
+	seed := h.Seed()
+	if seed == (Seed{}) {
+		t.Fatal("zero Hasher has a zero seed")
+	}
+	if got, want := h.Sum64(), Bytes(seed, data); got != want {
+		t.Errorf("Sum64() = %x, want Bytes() %x", got, want)
+	}
+	if got, want := h.Sum64(), StringSum(seed, string(data)); got != want {
+		t.Errorf("Sum64() = %x, want StringSum() %x", got, want)
+	}
+	want := HashSeed(seed, data)
+	if got := h.Sum128(); !bytes.Equal(got[:], want) {
+		t.Errorf("Sum128() = %x, want HashSeed() %x", got, want)
+	}
+	if got := Bytes(seed, data); got != binary.LittleEndian.Uint64(want) {
+		t.Errorf("Bytes() = %x, want the first 8 bytes of %x", got, want)
+	}
+
+	var other Hasher
+	other.Write(data)
+	if other.Seed() == seed {
+		t.Errorf("two zero Hashers got the same seed")
+	}
+}
+
+func TestHasherRestart(t *testing.T) {
+	seed := Seed(testSeed())
+	data := testData(600)
+	want := Bytes(seed, data)
+
+	var h Hasher
+	h.SetSeed(seed)
+	h.Write(testData(50))
+	h.Reset()
+	h.Write(data)
+	if got := h.Sum64(); got != want {
+		t.Errorf("Sum64() after Reset() = %x, want %x", got, want)
+	}
+	if h.Seed() != seed {
+		t.Errorf("Reset() changed the seed")
+	}
+
+	h.Write(testData(50))
+	h.SetSeed(seed)
+	h.Write(data)
+	if got := h.Sum64(); got != want {
+		t.Errorf("Sum64() after SetSeed() = %x, want %x", got, want)
+	}
+}
+
+func TestHasherWrites(t *testing.T) {
+	seed := Seed(testSeed())
+	data := testData(3*BlockSize + 17)
+	var h Hasher
+	h.SetSeed(seed)
+	h.WriteString(string(data[:100]))
+	for _, c := range data[100:400] {
+		h.WriteByte(c)
+	}
+	h.Write(data[400:700])
+	h.WriteString(string(data[700:]))
+	if got, want := h.Sum64(), Bytes(seed, data); got != want {
+		t.Errorf("mixed writes: Sum64() = %x, want %x", got, want)
+	}
+	var be [8]byte
+	binary.BigEndian.PutUint64(be[:], h.Sum64())
+	if got := h.Sum(nil); !bytes.Equal(got, be[:]) {
+		t.Errorf("Sum() = %x, want Sum64() big endian", got)
+	}
+
+	s := string(data)
+	if n := testing.AllocsPerRun(100, func() { StringSum(seed, s); h.WriteString(s); h.WriteByte(1) }); n != 0 {
+		t.Errorf("StringSum, WriteString and WriteByte allocate %v times, want 0", n)
+	}
+}
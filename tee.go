@@ -0,0 +1,119 @@
+package meow
+
+import "io"
+
+// HashingReader reads from an io.Reader and hashes the bytes read, using
+// MeowDefaultSeed, so data can be hashed as it is consumed.
+type HashingReader struct {
+	r io.Reader
+	h meowHash
+	n int64
+}
+
+// NewHashingReader returns a HashingReader reading from r.
+func NewHashingReader(r io.Reader) *HashingReader {
+	hr := &HashingReader{r: r}
+	hr.h.seed = MeowDefaultSeed
+	hr.h.Reset()
+	return hr
+}
+
+// Read reads from the underlying reader and hashes the bytes read.
+func (r *HashingReader) Read(p []byte) (int, error) {
+	return hashingReader{r.r, &r.h, &r.n}.Read(p)
+}
+
+// WriteTo implements io.WriterTo. It uses the underlying reader's WriteTo
+// if it has one, otherwise w's ReadFrom if it has one, so io.Copy keeps
+// its fast paths. In the first case only bytes accepted by w are hashed;
+// otherwise the bytes read are hashed, which differ from the bytes written
+// only if an error is returned.
+func (r *HashingReader) WriteTo(w io.Writer) (int64, error) {
+	if wt, ok := r.r.(io.WriterTo); ok {
+		return wt.WriteTo(hashingWriter{w, &r.h, &r.n})
+	}
+	return io.Copy(w, readerOnly{r})
+}
+
+// Sum returns the hash of the bytes read so far.
+func (r *HashingReader) Sum() Hash128 { return r.h.sum128() }
+
+// Count returns the number of bytes read so far.
+func (r *HashingReader) Count() int64 { return r.n }
+
+// HashingWriter writes to an io.Writer and hashes the bytes written, using
+// MeowDefaultSeed, so data can be hashed on its way to its destination.
+type HashingWriter struct {
+	w io.Writer
+	h meowHash
+	n int64
+}
+
+// NewHashingWriter returns a HashingWriter writing to w.
+func NewHashingWriter(w io.Writer) *HashingWriter {
+	hw := &HashingWriter{w: w}
+	hw.h.seed = MeowDefaultSeed
+	hw.h.Reset()
+	return hw
+}
+
+// Write writes p to the underlying writer and hashes the bytes written.
+func (w *HashingWriter) Write(p []byte) (int, error) {
+	return hashingWriter{w.w, &w.h, &w.n}.Write(p)
+}
+
+// ReadFrom implements io.ReaderFrom. It uses the underlying writer's
+// ReadFrom if it has one, otherwise r's WriteTo if it has one, so io.Copy
+// keeps its fast paths. In the first case the bytes read from r are
+// hashed, which differ from the bytes written only if an error is
+// returned.
+func (w *HashingWriter) ReadFrom(r io.Reader) (int64, error) {
+	if rf, ok := w.w.(io.ReaderFrom); ok {
+		return rf.ReadFrom(hashingReader{r, &w.h, &w.n})
+	}
+	return io.Copy(writerOnly{w}, r)
+}
+
+// Sum returns the hash of the bytes written so far.
+func (w *HashingWriter) Sum() Hash128 { return w.h.sum128() }
+
+// Count returns the number of bytes written so far.
+func (w *HashingWriter) Count() int64 { return w.n }
+
+// hashingReader reads from r and hashes the bytes read into h, adding
+// their count to n.
+type hashingReader struct {
+	r io.Reader
+	h *meowHash
+	n *int64
+}
+
+func (hr hashingReader) Read(p []byte) (int, error) {
+	n, err := hr.r.Read(p)
+	hr.h.Write(p[:n])
+	*hr.n += int64(n)
+	return n, err
+}
+
+// hashingWriter writes to w and hashes the bytes written into h, adding
+// their count to n.
+type hashingWriter struct {
+	w io.Writer
+	h *meowHash
+	n *int64
+}
+
+func (hw hashingWriter) Write(p []byte) (int, error) {
+	n, err := hw.w.Write(p)
+	hw.h.Write(p[:n])
+	*hw.n += int64(n)
+	return n, err
+}
+
+// readerOnly hides any methods but Read, so io.Copy can't call back into
+// the WriteTo of the reader it wraps.
+type readerOnly struct{ io.Reader }
+
+// writerOnly hides any methods but Write, so io.Copy can't call back into
+// the ReadFrom of the writer it wraps.
+type writerOnly struct{ io.Writer }
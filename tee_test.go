@@ -0,0 +1,139 @@
+package meow
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+// recordReader records whether its WriteTo was called.
+type recordReader struct {
+	*bytes.Reader
+	writeTo bool
+}
+
+func (r *recordReader) WriteTo(w io.Writer) (int64, error) {
+	r.writeTo = true
+	return r.Reader.WriteTo(w)
+}
+
+// recordWriter records whether its ReadFrom was called.
+type recordWriter struct {
+	bytes.Buffer
+	readFrom bool
+}
+
+func (w *recordWriter) ReadFrom(r io.Reader) (int64, error) {
+	w.readFrom = true
+	return w.Buffer.ReadFrom(r)
+}
+
+// shortWriter accepts n bytes, then fails.
+type shortWriter struct{ n int }
+
+var errShortWriter = errors.New("short writer")
+
+func (w *shortWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errShortWriter
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestHashingReader(t *testing.T) {
+	data := testData(100000)
+	want := Hash(data)
+	for _, tc := range []struct {
+		name               string
+		writerTo, readFrom bool
+	}{
+		{"plain", false, false},
+		{"WriterTo", true, false},
+		{"ReaderFrom", false, true},
+		{"both", true, true},
+	} {
+		rr := &recordReader{Reader: bytes.NewReader(data)}
+		var src io.Reader = readerOnly{rr}
+		if tc.writerTo {
+			src = rr
+		}
+		rw := &recordWriter{}
+		var dst io.Writer = writerOnly{rw}
+		if tc.readFrom {
+			dst = rw
+		}
+
+		hr := NewHashingReader(src)
+		n, err := io.Copy(dst, hr)
+		if err != nil || n != int64(len(data)) || !bytes.Equal(rw.Bytes(), data) {
+			t.Errorf("%s: io.Copy() = %d, %v, want %d bytes copied", tc.name, n, err, len(data))
+		}
+		// the reader's WriteTo is preferred over the writer's ReadFrom
+		if rr.writeTo != tc.writerTo || rw.readFrom != (tc.readFrom && !tc.writerTo) {
+			t.Errorf("%s: WriteTo called %v, ReadFrom called %v", tc.name, rr.writeTo, rw.readFrom)
+		}
+		if h := hr.Sum(); !bytes.Equal(h[:], want) || hr.Count() != int64(len(data)) {
+			t.Errorf("%s: Sum() = %x, Count() = %d, want %x, %d", tc.name, h, hr.Count(), want, len(data))
+		}
+	}
+
+	// with the reader's WriteTo, only the bytes w accepted are hashed
+	hr := NewHashingReader(bytes.NewReader(data))
+	if n, err := hr.WriteTo(&shortWriter{n: 1000}); n != 1000 || err != errShortWriter {
+		t.Errorf("WriteTo(short writer) = %d, %v, want 1000, %v", n, err, errShortWriter)
+	}
+	if h := hr.Sum(); !bytes.Equal(h[:], Hash(data[:1000])) || hr.Count() != 1000 {
+		t.Errorf("after a short write Sum() = %x, Count() = %d, want the first 1000 bytes", h, hr.Count())
+	}
+}
+
+func TestHashingWriter(t *testing.T) {
+	data := testData(100000)
+	want := Hash(data)
+	for _, tc := range []struct {
+		name               string
+		writerTo, readFrom bool
+	}{
+		{"plain", false, false},
+		{"WriterTo", true, false},
+		{"ReaderFrom", false, true},
+		{"both", true, true},
+	} {
+		rr := &recordReader{Reader: bytes.NewReader(data)}
+		var src io.Reader = readerOnly{rr}
+		if tc.writerTo {
+			src = rr
+		}
+		rw := &recordWriter{}
+		var dst io.Writer = writerOnly{rw}
+		if tc.readFrom {
+			dst = rw
+		}
+
+		hw := NewHashingWriter(dst)
+		n, err := io.Copy(hw, src)
+		if err != nil || n != int64(len(data)) || !bytes.Equal(rw.Bytes(), data) {
+			t.Errorf("%s: io.Copy() = %d, %v, want %d bytes copied", tc.name, n, err, len(data))
+		}
+		// io.Copy prefers the reader's WriteTo, which writes through hw
+		if rr.writeTo != tc.writerTo || rw.readFrom != (tc.readFrom && !tc.writerTo) {
+			t.Errorf("%s: WriteTo called %v, ReadFrom called %v", tc.name, rr.writeTo, rw.readFrom)
+		}
+		if h := hw.Sum(); !bytes.Equal(h[:], want) || hw.Count() != int64(len(data)) {
+			t.Errorf("%s: Sum() = %x, Count() = %d, want %x, %d", tc.name, h, hw.Count(), want, len(data))
+		}
+	}
+
+	// a plain Write hashes only the bytes the writer accepted
+	hw := NewHashingWriter(&shortWriter{n: 1000})
+	if n, err := hw.Write(data); n != 1000 || err != errShortWriter {
+		t.Errorf("Write(short writer) = %d, %v, want 1000, %v", n, err, errShortWriter)
+	}
+	if h := hw.Sum(); !bytes.Equal(h[:], Hash(data[:1000])) || hw.Count() != 1000 {
+		t.Errorf("after a short write Sum() = %x, Count() = %d, want the first 1000 bytes", h, hw.Count())
+	}
+}
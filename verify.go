@@ -0,0 +1,102 @@
+package meow
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var (
+	// ErrHashMismatch is returned by a VerifyingReader, wrapped in a
+	// *MismatchError, when the data doesn't have the expected hash.
+	ErrHashMismatch = errors.New("meow: hash mismatch")
+
+	// ErrLengthMismatch is returned by a VerifyingReader, wrapped with
+	// the lengths, when the data isn't the declared length.
+	ErrLengthMismatch = errors.New("meow: length mismatch")
+)
+
+// MismatchError reports the expected and actual hash of data that failed
+// verification. errors.Is(err, ErrHashMismatch) reports true for it.
+type MismatchError struct {
+	Expected Hash128
+	Actual   Hash128
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("meow: hash mismatch: expected %v, got %v", e.Expected, e.Actual)
+}
+
+// Unwrap returns ErrHashMismatch.
+func (e *MismatchError) Unwrap() error { return ErrHashMismatch }
+
+// VerifyingReader reads from an io.Reader and checks the data has the
+// expected hash, using MeowDefaultSeed. At the end of the data Read
+// returns a *MismatchError instead of io.EOF if the hash differs, so a
+// consumer such as io.Copy or ioutil.ReadAll fails rather than silently
+// accepting corrupt data. Errors are sticky: later calls to Read return
+// the same error.
+type VerifyingReader struct {
+	r        io.Reader
+	h        meowHash
+	n        int64
+	expected Hash128
+	size     int64 // declared length, or -1
+	err      error
+}
+
+// NewVerifyingReader returns a VerifyingReader reading from r that expects
+// the data to hash to expected.
+func NewVerifyingReader(r io.Reader, expected Hash128) *VerifyingReader {
+	return NewVerifyingReaderSize(r, expected, -1)
+}
+
+// NewVerifyingReaderSize is like NewVerifyingReader(), but also expects
+// the data to be size bytes long, unless size is negative. The length is
+// checked as data arrives: Read never returns bytes past size, and
+// returns an error wrapping ErrLengthMismatch as soon as the data is
+// found to be longer, or at io.EOF if it is shorter, before the hash is
+// compared.
+func NewVerifyingReaderSize(r io.Reader, expected Hash128, size int64) *VerifyingReader {
+	vr := &VerifyingReader{r: r, expected: expected, size: size}
+	vr.h.seed = MeowDefaultSeed
+	vr.h.Reset()
+	return vr
+}
+
+// Read reads from the underlying reader, returning a *MismatchError or an
+// error wrapping ErrLengthMismatch instead of io.EOF if the data doesn't
+// verify.
+func (r *VerifyingReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.r.Read(p)
+	if r.size >= 0 && r.n+int64(n) > r.size {
+		n = int(r.size - r.n)
+		err = fmt.Errorf("%w: more than %d bytes", ErrLengthMismatch, r.size)
+	}
+	r.h.Write(p[:n])
+	r.n += int64(n)
+
+	if err == io.EOF {
+		err = r.verify()
+	}
+	r.err = err
+	return n, err
+}
+
+// verify checks the data once it is all read. It returns io.EOF if it
+// verifies.
+func (r *VerifyingReader) verify() error {
+	if r.size >= 0 && r.n != r.size {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrLengthMismatch, r.size, r.n)
+	}
+	if actual := Hash128(r.h.sum128()); !actual.Equal(r.expected) {
+		return &MismatchError{Expected: r.expected, Actual: actual}
+	}
+	return io.EOF
+}
+
+// Count returns the number of bytes read so far.
+func (r *VerifyingReader) Count() int64 { return r.n }
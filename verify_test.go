@@ -0,0 +1,85 @@
+package meow
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+)
+
+func TestVerifyingReader(t *testing.T) {
+	data := testData(10000)
+	want := HashString(string(data))
+
+	vr := NewVerifyingReader(bytes.NewReader(data), want)
+	got, err := ioutil.ReadAll(vr)
+	if err != nil || !bytes.Equal(got, data) || vr.Count() != int64(len(data)) {
+		t.Errorf("ReadAll(match) = %d bytes, %v, want %d bytes", len(got), err, len(data))
+	}
+	if n, err := vr.Read(make([]byte, 10)); n != 0 || err != io.EOF {
+		t.Errorf("Read() after a match = %d, %v, want 0, io.EOF", n, err)
+	}
+
+	bad := want
+	bad[0] ^= 1
+	vr = NewVerifyingReader(iotest.OneByteReader(bytes.NewReader(data)), bad)
+	got, err = ioutil.ReadAll(vr)
+	var me *MismatchError
+	if !errors.As(err, &me) || !errors.Is(err, ErrHashMismatch) || errors.Is(err, ErrLengthMismatch) {
+		t.Fatalf("ReadAll(mismatch) = %v, want a *MismatchError", err)
+	}
+	if me.Expected != bad || me.Actual != want || len(got) != len(data) {
+		t.Errorf("ReadAll(mismatch) = %d bytes, %+v, want %d bytes, expected %v, actual %v", len(got), me, len(data), bad, want)
+	}
+	// errors are sticky
+	for i := 0; i < 2; i++ {
+		if n, err2 := vr.Read(make([]byte, 10)); n != 0 || err2 != err {
+			t.Errorf("Read() after a mismatch = %d, %v, want 0, %v", n, err2, err)
+		}
+	}
+}
+
+func TestVerifyingReaderSize(t *testing.T) {
+	data := testData(10000)
+	want := HashString(string(data))
+
+	vr := NewVerifyingReaderSize(bytes.NewReader(data), want, int64(len(data)))
+	if got, err := ioutil.ReadAll(vr); err != nil || !bytes.Equal(got, data) {
+		t.Errorf("ReadAll(exact size) = %d bytes, %v, want %d bytes", len(got), err, len(data))
+	}
+
+	// longer: stopped at size, before the underlying reader hits io.EOF
+	for _, r := range []func(io.Reader) io.Reader{
+		func(r io.Reader) io.Reader { return r },
+		iotest.OneByteReader,
+	} {
+		br := bytes.NewReader(append(data, 'x'))
+		vr = NewVerifyingReaderSize(r(br), want, int64(len(data)))
+		got, err := ioutil.ReadAll(vr)
+		if !errors.Is(err, ErrLengthMismatch) || errors.Is(err, ErrHashMismatch) {
+			t.Errorf("ReadAll(longer) error = %v, want %v", err, ErrLengthMismatch)
+		}
+		if !bytes.Equal(got, data) || vr.Count() != int64(len(data)) {
+			t.Errorf("ReadAll(longer) = %d bytes, Count() = %d, want the first %d", len(got), vr.Count(), len(data))
+		}
+		if n, err2 := vr.Read(make([]byte, 10)); n != 0 || err2 != err {
+			t.Errorf("Read() after a length mismatch = %d, %v, want 0, %v", n, err2, err)
+		}
+	}
+	br := bytes.NewReader(data)
+	vr = NewVerifyingReaderSize(iotest.OneByteReader(br), want, 100)
+	if got, err := ioutil.ReadAll(vr); !errors.Is(err, ErrLengthMismatch) || len(got) != 100 || br.Len() != len(data)-101 {
+		t.Errorf("ReadAll(much longer) = %d bytes, %v, %d unread, want 100 bytes, %v, %d unread",
+			len(got), err, br.Len(), ErrLengthMismatch, len(data)-101)
+	}
+
+	// shorter: found at io.EOF, reported instead of a hash mismatch
+	short := data[:len(data)-1]
+	vr = NewVerifyingReaderSize(bytes.NewReader(short), want, int64(len(data)))
+	got, err := ioutil.ReadAll(vr)
+	if !errors.Is(err, ErrLengthMismatch) || errors.Is(err, ErrHashMismatch) || !bytes.Equal(got, short) {
+		t.Errorf("ReadAll(shorter) = %d bytes, %v, want %d bytes, %v", len(got), err, len(short), ErrLengthMismatch)
+	}
+}